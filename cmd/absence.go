@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/holiday"
+	"eager/pkg/jira"
+	"encoding/csv"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func init() {
+	rootCmd.AddCommand(absenceCmd)
+	absenceCmd.AddCommand(absenceShowCmd, absenceBookCmd)
+	absenceBookCmd.AddCommand(absenceBookJiraCmd)
+
+	absenceCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year")
+	absenceCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month")
+}
+
+var absenceCmd = &cobra.Command{
+	Use:   "absence",
+	Short: "Absences",
+	Long:  "Show and book public holidays and absences of the calendar configuration.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var absenceShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show absences",
+	Long:  "Show public holidays and absences of the current user.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		absences, err := absences()
+		if err != nil {
+			return err
+		}
+		csvw := csv.NewWriter(os.Stdout)
+		csvw.Comma = ';'
+		for _, absence := range absences {
+			err = csvw.Write([]string{absence.Date.Format(pkg.IsoYearMonthDay), string(absence.Kind), absence.Name})
+			if err != nil {
+				log.Println(err)
+			}
+		}
+		csvw.Flush()
+		return nil
+	},
+}
+
+var absenceBookCmd = &cobra.Command{
+	Use:   "book",
+	Short: "Book absences",
+	Long:  "Book the absences of the current user with the working time of that day on the task configured for its kind.",
+	Args:  cobra.NoArgs,
+}
+
+var absenceBookJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Book absences to Jira",
+	Long:  "Book the absences of the current user to Atlassian Jira.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(conf.Calendar.Tasks) == 0 {
+			return fmt.Errorf("there are no tasks for absences (calendar.tasks) inside your configuration")
+		}
+		workingTimes, err := pkg.NewWorkingTimes(conf.WorkingTimes)
+		if err != nil {
+			return err
+		}
+		workingTime := workingTimes.Get(&pkg.User{})
+		if workingTime == nil {
+			return fmt.Errorf("there is no working time (worktime) for the current user inside your configuration")
+		}
+		absences, err := absences()
+		if err != nil {
+			return err
+		}
+		var timesheet pkg.Timesheet
+		for _, absence := range absences {
+			task := conf.Calendar.Tasks[string(absence.Kind)]
+			duration := workingTime.Target(absence.Date)
+			if task == "" || duration == 0 {
+				continue
+			}
+			timesheet = append(timesheet, pkg.Effort{
+				Task:        pkg.Task(task),
+				Description: pkg.Description(absence.String()),
+				Date:        absence.Date,
+				Duration:    duration,
+			})
+		}
+		bookings, err := jira.AddTimesheet(
+			pkg.NewHttpClient(),
+			conf.Server(),
+			conf.Userinfo(),
+			timesheet,
+			changes,
+		)
+		if err != nil {
+			return err
+		}
+		pkg.WriteBookings(os.Stdout, bookings, &conf.Duration)
+		return nil
+	},
+}
+
+func absences() ([]pkg.Absence, error) {
+	calendar, err := holidays()
+	if err != nil {
+		return nil, err
+	}
+	return calendar.Absences(&pkg.User{}, conf.Year, time.Month(conf.Month)), nil
+}
+
+// calendar returns the calendar of the configuration or nil, if there is none.
+func calendar() (pkg.Calendar, error) {
+	if conf.Calendar.Region == "" && len(conf.Calendar.Absences) == 0 {
+		return nil, nil
+	}
+	return holidays()
+}
+
+func holidays() (*holiday.Calendar, error) {
+	calendar, err := holiday.New(conf.Calendar.Region)
+	if err != nil {
+		return nil, err
+	}
+	for _, absence := range conf.Calendar.Absences {
+		kind := pkg.AbsenceKind(absence.Kind)
+		switch kind {
+		case "":
+			kind = pkg.AbsenceVacation
+		case pkg.AbsenceVacation, pkg.AbsenceSick, pkg.AbsenceHoliday:
+		default:
+			return nil, fmt.Errorf("unknown absence kind '%s'", absence.Kind)
+		}
+		path := absence.File
+		if !filepath.IsAbs(path) && viper.ConfigFileUsed() != "" {
+			// Relative to the configuration file
+			path = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), path)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read absences %s", path)
+		}
+		absences, err := holiday.ReadIcs(file, kind)
+		_ = file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read absences %s. %s", path, err.Error())
+		}
+		calendar.Add(absence.User, absences...)
+	}
+	return calendar, nil
+}
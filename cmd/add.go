@@ -5,21 +5,30 @@ import (
 	"eager/pkg"
 	"eager/pkg/cli"
 	"eager/pkg/jira"
+	"eager/pkg/tempo"
 	"fmt"
 	"github.com/spf13/cobra"
+	"strings"
 	"time"
 )
 
 func init() {
 	rootCmd.AddCommand(addCmd)
-	addCmd.AddCommand(addJiraCmd)
+	addCmd.AddCommand(addJiraCmd, addTempoCmd)
 
 	addCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year")
 	addCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month")
 	addCmd.PersistentFlags().IntVar(&conf.Day, internal.FlagDay, time.Now().Day(), "specify the day")
 	addCmd.PersistentFlags().StringVar(&conf.Task, internal.FlagTask, "", "specify the task")
 	addCmd.PersistentFlags().BoolVarP(&conf.Duration.Summarize, internal.FlagSummarize, "s", false, "sum effort on same day and task")
+	addCmd.PersistentFlags().DurationVar(&conf.Duration.Rounding.Unit, internal.FlagRound, 0, "round duration to a multiple of the given unit (e.g. 6m, 15m or 30m)")
+	addCmd.PersistentFlags().StringVar(&conf.Duration.Rounding.Mode, internal.FlagRoundMode, pkg.RoundNearest, "specify the rounding mode (up, down or nearest)")
+	addCmd.PersistentFlags().StringVar(&conf.Duration.Rounding.Scope, internal.FlagRoundScope, pkg.RoundEntry, "specify the rounding scope (entry or day)")
 	addCmd.MarkFlagRequired(internal.FlagTask)
+
+	addTempoCmd.Flags().DurationVar(&conf.Billable, internal.FlagBillable, 0, "specify the billable duration (default is the duration)")
+	addTempoCmd.Flags().StringArrayVar(&conf.Attributes, internal.FlagAttributes, nil, "specify the value of a work attribute (key=value)")
+	tempoFlags(addTempoCmd)
 }
 
 var addCmd = &cobra.Command{
@@ -33,7 +42,7 @@ var addCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		return nil
+		return validateRounding()
 	},
 }
 
@@ -47,7 +56,7 @@ var addJiraCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("not a valid duration '%s'", args[0])
 		}
-		jira.AddWorklogItem(
+		return jira.AddWorklogItem(
 			pkg.NewHttpClient(),
 			conf.Server(),
 			conf.Userinfo(),
@@ -57,8 +66,57 @@ var addJiraCmd = &cobra.Command{
 			pkg.Task(conf.Task),
 			duration,
 			conf.Duration.Summarize,
-			cli.Confirmation,
+			conf.Duration.Rounding,
+			cli.Confirmation(conf.Yes, conf.NoInput),
+			changes,
+		)
+	},
+}
+
+var addTempoCmd = &cobra.Command{
+	Use:   "tempo",
+	Short: "Add worklog item to Tempo",
+	Long:  "Add a worklog item with billable duration and work attributes to Tempo Timesheets.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		duration, err := time.ParseDuration(args[0])
+		if err != nil {
+			return fmt.Errorf("not a valid duration '%s'", args[0])
+		}
+		if conf.Duration.Summarize {
+			return fmt.Errorf("summaries (--%s) are not available for Tempo", internal.FlagSummarize)
+		}
+		duration, err = pkg.RoundBooking(duration, conf.Duration.Rounding)
+		if err != nil {
+			return err
+		}
+		billable := duration
+		if cmd.Flags().Changed(internal.FlagBillable) {
+			billable = conf.Billable
+		}
+		attributes := map[string]string{}
+		for _, attribute := range conf.Attributes {
+			parts := strings.SplitN(attribute, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return fmt.Errorf("not a valid work attribute '%s'", attribute)
+			}
+			attributes[parts[0]] = parts[1]
+		}
+		return tempo.AddWorklogItem(
+			pkg.NewHttpClient(),
+			conf.Server(),
+			conf.Userinfo(),
+			conf.TempoToken,
+			conf.TempoUrl,
+			conf.Year,
+			time.Month(conf.Month),
+			conf.Day,
+			pkg.Task(conf.Task),
+			duration,
+			billable,
+			"",
+			attributes,
+			changes,
 		)
-		return nil
 	},
 }
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+func init() {
+	rootCmd.AddCommand(balanceCmd)
+	balanceCmd.AddCommand(balanceBcsCmd, balanceJiraCmd)
+
+	balanceCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year to calculate the balance for")
+	balanceCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month to calculate the balance for")
+	balanceCmd.PersistentFlags().StringVar(&conf.Period, internal.FlagPeriod, pkg.PeriodDay, "specify the period to sum up (day, week or month)")
+	balanceCmd.PersistentFlags().BoolVar(&conf.Duration.Decimal, internal.FlagDecimal, false, "display duration as decimal hour")
+	balanceCmd.PersistentFlags().BoolVar(&conf.Duration.Negate, internal.FlagNegate, false, "negate decimal duration")
+
+	balanceBcsCmd.Flags().StringVar(&conf.Report, internal.FlagReport, "", "specify the name of the report")
+	balanceBcsCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the oid of the project")
+	balanceBcsCmd.MarkFlagRequired(internal.FlagReport)
+
+	balanceJiraCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the project key")
+	balanceJiraCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "show results for user (or user=id, where id is the account id)")
+	jiraQueryFlags(balanceJiraCmd)
+}
+
+var balanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Show balance of working time",
+	Long:  "Show target, actual and overtime of the configured working time from the given store.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		if !conf.Duration.Decimal && conf.Duration.Negate {
+			return fmt.Errorf("negative durations (--%s) are only available for decimal values (--%s)", internal.FlagNegate, internal.FlagDecimal)
+		}
+		if len(conf.WorkingTimes) == 0 {
+			return fmt.Errorf("there is no working time (worktime) inside your configuration")
+		}
+		return pkg.ValidatePeriod(conf.Period)
+	},
+}
+
+var balanceBcsCmd = &cobra.Command{
+	Use:   "bcs",
+	Short: "Show balance from BCS",
+	Long:  "Show the balance of your worklog data from Projektron BCS.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := cmd.Parent().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return validateBcs()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeBalance(bcsTimesheetBetween, nil, len(conf.Projects) > 0)
+	},
+}
+
+var balanceJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Show balance from Jira",
+	Long:  "Show the balance of your worklog data from Atlassian Jira.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		users := pkg.Users(conf.Users)
+		return writeBalance(jiraTimesheetBetween, users, len(users) > 0)
+	},
+}
+
+// writeBalance prints the balance of the month. The effort since the start of the working time is read at once to carry over the overtime.
+func writeBalance(timesheet func(fromDate, toDate time.Time) (pkg.Timesheet, error), users []*pkg.User, user bool) error {
+	workingTimes, err := pkg.NewWorkingTimes(conf.WorkingTimes)
+	if err != nil {
+		return err
+	}
+	calendar, err := calendar()
+	if err != nil {
+		return err
+	}
+	year, month := conf.Year, time.Month(conf.Month)
+	fromDate, toDate := pkg.GetTimeRange(year, month)
+	current, err := timesheet(fromDate, toDate)
+	if err != nil {
+		return err
+	}
+
+	opening := pkg.Timesheet{}
+	since := workingTimes.Since(fromDate)
+	if since.Before(fromDate) {
+		opening, err = timesheet(since, fromDate)
+		if err != nil {
+			return err
+		}
+	}
+	all := append(current.Users(), users...)
+	carryover := opening.Carryover(fromDate, all, workingTimes, calendar)
+
+	pkg.WriteBalance(os.Stdout, current.Balance(year, month, conf.Period, users, workingTimes, calendar, carryover), user, &conf.Duration)
+	return nil
+}
+
+// bcsTimesheetBetween returns the effort between both days, excluding the last day. Projektron BCS reports a single month at once.
+func bcsTimesheetBetween(fromDate, toDate time.Time) (pkg.Timesheet, error) {
+	var timesheet pkg.Timesheet
+	for month := time.Date(fromDate.Year(), fromDate.Month(), 1, 0, 0, 0, 0, time.UTC); month.Before(toDate); month = month.AddDate(0, 1, 0) {
+		efforts, err := bcsTimesheet(month.Year(), month.Month())
+		if err != nil {
+			return nil, err
+		}
+		for _, effort := range efforts {
+			if !effort.Date.Before(fromDate) && effort.Date.Before(toDate) {
+				timesheet = append(timesheet, effort)
+			}
+		}
+	}
+	return timesheet, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/cli"
+	"fmt"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+func init() {
+	rootCmd.AddCommand(bookCmd)
+	bookCmd.AddCommand(newBookCmd(storeJira), newBookCmd(storeTempo))
+
+	bookCmd.PersistentFlags().StringVar(&conf.Week, internal.FlagWeek, pkg.IsoWeek(time.Now()), "specify the week of the weekdays (e.g. 2022-W31)")
+	bookCmd.PersistentFlags().BoolVar(&conf.Duration.Decimal, internal.FlagDecimal, false, "display duration as decimal hour")
+}
+
+var bookCmd = &cobra.Command{
+	Use:   "book",
+	Short: "Book a week",
+	Long:  "Book the efforts of a week from a text file with one effort per line like 'Mon PROJ-12 1h30 fix login'.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		_, err = pkg.ParseIsoWeek(conf.Week)
+		return err
+	},
+}
+
+// newBookCmd returns the command to book a week inside the store.
+func newBookCmd(store string) *cobra.Command {
+	name := map[string]string{storeJira: "Jira", storeTempo: "Tempo"}[store]
+	cmd := &cobra.Command{
+		Use:   store + " <file>",
+		Short: fmt.Sprintf("Book a week to %s", name),
+		Long:  fmt.Sprintf("Book the efforts of a week from a text file to %s. Nothing is booked, if a line or an issue is invalid.", name),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			monday, err := pkg.ParseIsoWeek(conf.Week)
+			if err != nil {
+				return err
+			}
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot read file %s", args[0])
+			}
+			provider, err := provider(store)
+			if err != nil {
+				return err
+			}
+			bookings := pkg.ReadWeek(data, monday)
+			err = pkg.Book(provider, bookings, cli.Confirmation(conf.Yes, conf.NoInput))
+			pkg.WriteBookings(os.Stdout, bookings, &conf.Duration)
+			return err
+		},
+	}
+	if store == storeTempo {
+		tempoFlags(cmd)
+	}
+	return cmd
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.AddCommand(checkBcsCmd, checkJiraCmd)
+
+	checkCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year to check effort for")
+	checkCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month to check effort for")
+
+	checkBcsCmd.Flags().StringVar(&conf.Report, internal.FlagReport, "", "specify the name of the report")
+	checkBcsCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the oid of the project")
+	checkBcsCmd.MarkFlagRequired(internal.FlagReport)
+
+	checkJiraCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the project key")
+	checkJiraCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "check results for user (or user=id, where id is the account id)")
+	jiraQueryFlags(checkJiraCmd)
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check worklog against working time law",
+	Long:  "Check the worklog from the given store against the maximum daily effort, breaks, rest times, sundays and public holidays.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var checkBcsCmd = &cobra.Command{
+	Use:   "bcs",
+	Short: "Check worklog from BCS",
+	Long:  "Check your worklog data from Projektron BCS. BCS has no start times, so breaks and rest times are not checked.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := cmd.Parent().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return validateBcs()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := bcsTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return check(timesheet, len(conf.Projects) > 0)
+	},
+}
+
+var checkJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Check worklog from Jira",
+	Long:  "Check your worklog data from Atlassian Jira.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := jiraTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return check(timesheet, len(conf.Users) > 0)
+	},
+}
+
+func check(timesheet pkg.Timesheet, user bool) error {
+	calendar, err := calendar()
+	if err != nil {
+		return err
+	}
+	violations := timesheet.Check(pkg.NewRules(conf.Check), calendar)
+	pkg.WriteViolations(os.Stdout, violations, user)
+	if len(violations) > 0 {
+		return fmt.Errorf("found %d violations", len(violations))
+	}
+	return nil
+}
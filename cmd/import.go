@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/jira"
+	"fmt"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+)
+
+var importColumns = []string{"date", "project", "task", "duration", "description"}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importJiraCmd)
+
+	importCmd.PersistentFlags().BoolVar(&conf.Duration.Decimal, internal.FlagDecimal, false, "display duration as decimal hour")
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import worklog",
+	Long:  "Import efforts from a csv file into the given store.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var importJiraCmd = &cobra.Command{
+	Use:   "jira <file>",
+	Short: "Import worklog into Jira",
+	Long:  "Import efforts from a csv file into Atlassian Jira. Efforts, which are already inside the worklog, are skipped.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := readImport(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Validate every row and book the valid ones
+		bookings := make([]pkg.Booking, len(timesheet))
+		var valid pkg.Timesheet
+		var index []int
+		for i, effort := range timesheet {
+			bookings[i] = pkg.Booking{Row: i + 1, Effort: effort}
+			if conf.Import.Header {
+				bookings[i].Row++
+			}
+			switch {
+			case effort.Task == "":
+				bookings[i].Message = "missing task"
+			case effort.Date.IsZero():
+				bookings[i].Message = "invalid date"
+			case effort.Duration <= 0:
+				bookings[i].Message = "invalid duration"
+			default:
+				valid = append(valid, effort)
+				index = append(index, i)
+				continue
+			}
+			bookings[i].Status = pkg.BookingInvalid
+		}
+		if len(valid) > 0 {
+			result, err := jira.AddTimesheet(
+				pkg.NewHttpClient(),
+				conf.Server(),
+				conf.Userinfo(),
+				valid,
+				changes,
+			)
+			if err != nil {
+				return err
+			}
+			for i, booking := range result {
+				bookings[index[i]].Status = booking.Status
+				bookings[index[i]].Message = booking.Message
+			}
+		}
+		pkg.WriteBookings(os.Stdout, bookings, &conf.Duration)
+
+		failed := 0
+		for _, booking := range bookings {
+			if booking.Status == pkg.BookingInvalid || booking.Status == pkg.BookingFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d efforts could not be imported", failed, len(bookings))
+		}
+		return nil
+	},
+}
+
+func readImport(file string) (pkg.Timesheet, error) {
+	columns := conf.Import.Columns
+	if len(columns) == 0 {
+		columns = importColumns
+	}
+	spec, err := pkg.ParseCsvSpecification(columns)
+	if err != nil {
+		return nil, err
+	}
+	layout := conf.Import.DateFormat
+	if layout == "" {
+		layout = pkg.IsoYearMonthDay
+	}
+	spec = spec.Header(conf.Import.Header).DateFormat(layout)
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read file %s", file)
+	}
+	timesheet, err := pkg.Timesheet{}.ReadCsv(data, &spec)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse file %s. %s", file, err.Error())
+	}
+	return timesheet, nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+func init() {
+	rootCmd.AddCommand(invoiceCmd)
+	invoiceCmd.AddCommand(invoiceBcsCmd, invoiceJiraCmd, invoiceTempoCmd)
+
+	invoiceCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year to invoice")
+	invoiceCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month to invoice")
+	invoiceCmd.PersistentFlags().StringVar(&conf.Output, internal.FlagOutput, outputCsv, "specify the output format (csv, json or html)")
+
+	invoiceBcsCmd.Flags().StringVar(&conf.Report, internal.FlagReport, "", "specify the name of the report")
+	invoiceBcsCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the oid of the project")
+	invoiceBcsCmd.MarkFlagRequired(internal.FlagReport)
+
+	invoiceJiraCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the project key")
+	invoiceJiraCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "invoice effort of user (or user=id, where id is the account id)")
+	jiraQueryFlags(invoiceJiraCmd)
+	invoiceJiraCmd.Flags().StringArrayVar(&conf.Fields, internal.FlagFields, nil, "specify an issue field to keep as attribute (or name=field), e.g. customer=customfield_10100")
+	invoiceJiraCmd.Flags().BoolVar(&conf.Map, internal.FlagMap, false, "map project and task with the mapping configuration")
+
+	invoiceTempoCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "invoice effort of user (or user=id, where id is the account id)")
+	invoiceTempoCmd.Flags().StringArrayVar(&conf.Fields, internal.FlagFields, nil, "specify a work attribute key to use as attribute (or name=key), e.g. customer=_Customer_")
+	tempoFlags(invoiceTempoCmd)
+}
+
+var invoiceCmd = &cobra.Command{
+	Use:   "invoice",
+	Short: "Invoice worklog",
+	Long:  "Create the line items of an invoice with hours, rate and amount from the billable worklog of the given store.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		if len(conf.Rates) == 0 {
+			return fmt.Errorf("there are no rates inside the configuration")
+		}
+		switch conf.Output {
+		case outputCsv, outputJson, outputHtml:
+		default:
+			return fmt.Errorf("unknown output format '%s'", conf.Output)
+		}
+		return nil
+	},
+}
+
+var invoiceBcsCmd = &cobra.Command{
+	Use:   "bcs",
+	Short: "Invoice worklog from BCS",
+	Long:  "Invoice your worklog data from Projektron BCS.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := cmd.Parent().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return validateBcs()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := bcsTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return writeInvoice(timesheet)
+	},
+}
+
+var invoiceJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Invoice worklog from Jira",
+	Long:  "Invoice your worklog data from Atlassian Jira.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := jiraTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return writeInvoice(timesheet)
+	},
+}
+
+var invoiceTempoCmd = &cobra.Command{
+	Use:   "tempo",
+	Short: "Invoice worklog from Tempo",
+	Long:  "Invoice your worklog data from Tempo Timesheets with the billable duration of every worklog.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := tempoTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return writeInvoice(timesheet)
+	},
+}
+
+func writeInvoice(timesheet pkg.Timesheet) error {
+	invoice, err := timesheet.Invoice(conf.Year, time.Month(conf.Month), pkg.Rates(conf.Rates), conf.Billing.Currency)
+	if err != nil {
+		return err
+	}
+	switch conf.Output {
+	case outputJson:
+		return invoice.WriteJson(os.Stdout)
+	case outputHtml:
+		return invoice.WriteHtml(os.Stdout)
+	default:
+		return invoice.WriteCsv(os.Stdout)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/jira"
+	"fmt"
+	"github.com/spf13/cobra"
+	"sort"
+	"strings"
+)
+
+func init() {
+	rootCmd.AddCommand(mappingCmd)
+	mappingCmd.AddCommand(mappingTestCmd)
+
+	mappingTestCmd.Flags().StringArrayVar(&conf.Fields, internal.FlagFields, nil, "specify an issue field to match (or name=field)")
+}
+
+var mappingCmd = &cobra.Command{
+	Use:   "mapping",
+	Short: "Mapping of issues",
+	Long:  "Mapping of Jira issues to BCS projects and tasks.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var mappingTestCmd = &cobra.Command{
+	Use:   "test <issue>",
+	Short: "Test mapping of an issue",
+	Long:  "Show the fields of a Jira issue and the table entry or rule of the mapping configuration, that matches.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mapping, err := pkg.NewMapping(conf.Mapping, pkg.AttributeNames(pkg.Attributes(conf.Fields)))
+		if err != nil {
+			return err
+		}
+		fields, match, err := jira.MapIssue(
+			pkg.NewHttpClient(),
+			conf.Server(),
+			conf.Userinfo(),
+			pkg.Task(args[0]),
+			pkg.Attributes(conf.Fields),
+			mapping,
+		)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("%s: %s\n", name, strings.Join(fields[name], ", "))
+		}
+		if match == nil {
+			return fmt.Errorf("no mapping for %s", args[0])
+		}
+		fmt.Printf("Matched %s\n", match.Rule)
+		fmt.Printf("Project: %s\nTask: %s\n", match.Project, match.Task)
+		return nil
+	},
+}
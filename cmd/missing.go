@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/notify"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+	"time"
+)
+
+func init() {
+	rootCmd.AddCommand(missingCmd)
+	missingCmd.AddCommand(missingBcsCmd, missingJiraCmd)
+
+	missingCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year to query effort for")
+	missingCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month to query effort for")
+	missingCmd.PersistentFlags().DurationVar(&conf.Threshold, internal.FlagThreshold, 0, "specify the minimum effort per day, otherwise only days without effort are missing")
+	missingCmd.PersistentFlags().StringVar(&conf.Notify, internal.FlagNotify, notify.KindText, "specify the notifier (text, desktop or command)")
+	missingCmd.PersistentFlags().BoolVar(&conf.Duration.Decimal, internal.FlagDecimal, false, "display duration as decimal hour")
+
+	missingBcsCmd.Flags().StringVar(&conf.Report, internal.FlagReport, "", "specify the name of the report")
+	missingBcsCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the oid of the project")
+	missingBcsCmd.MarkFlagRequired(internal.FlagReport)
+
+	missingJiraCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the project key")
+	missingJiraCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "show results for user (or user=id, where id is the account id)")
+	jiraQueryFlags(missingJiraCmd)
+}
+
+var missingCmd = &cobra.Command{
+	Use:   "missing",
+	Short: "Show missing effort",
+	Long:  "Show working days until today without effort or with effort below the threshold. Nothing is reported, if there is no missing effort.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		if conf.Threshold < 0 {
+			return fmt.Errorf("the threshold (--%s) must not be negative", internal.FlagThreshold)
+		}
+		return nil
+	},
+}
+
+var missingBcsCmd = &cobra.Command{
+	Use:   "bcs",
+	Short: "Show missing effort in BCS",
+	Long:  "Show missing effort of your worklog data from Projektron BCS.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := cmd.Parent().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return validateBcs()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := bcsTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return missing(timesheet, nil, len(conf.Projects) > 0)
+	},
+}
+
+var missingJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Show missing effort in Jira",
+	Long:  "Show missing effort of your worklog data from Atlassian Jira.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		users := pkg.Users(conf.Users)
+		timesheet, err := jiraTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return missing(timesheet, users, len(users) > 0)
+	},
+}
+
+func missing(timesheet pkg.Timesheet, users []*pkg.User, user bool) error {
+	notifier, err := notify.New(conf.Notify, os.Stdout, conf.NotifyCommand)
+	if err != nil {
+		return err
+	}
+	workingTimes, err := pkg.NewWorkingTimes(conf.WorkingTimes)
+	if err != nil {
+		return err
+	}
+	calendar, err := calendar()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	missing := timesheet.Missing(conf.Year, time.Month(conf.Month), tomorrow, users, conf.Threshold, workingTimes, calendar)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var message string
+	if conf.Notify == notify.KindText {
+		buffer := new(bytes.Buffer)
+		pkg.WriteMissing(buffer, missing, user, &conf.Duration)
+		message = strings.TrimSuffix(buffer.String(), "\n")
+	} else {
+		// One line per user with every missing day
+		var lines []string
+		var name string
+		for i, m := range missing {
+			current := ""
+			if m.User != nil {
+				current = m.User.DisplayName
+			}
+			if i == 0 || current != name {
+				name = current
+				line := m.Date.Format(pkg.IsoYearMonthDay)
+				if user {
+					line = name + ": " + line
+				}
+				lines = append(lines, line)
+				continue
+			}
+			lines[len(lines)-1] += ", " + m.Date.Format(pkg.IsoYearMonthDay)
+		}
+		message = strings.Join(lines, "\n")
+	}
+	err = notifier.Notify(fmt.Sprintf("Missing effort on %d days", len(missing)), message)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("missing effort on %d days", len(missing))
+}
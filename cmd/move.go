@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/cli"
+	"eager/pkg/jira"
+	"eager/pkg/tempo"
+	"fmt"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+const (
+	storeJira  = "jira"
+	storeTempo = "tempo"
+)
+
+func init() {
+	rootCmd.AddCommand(moveCmd, copyCmd)
+	moveCmd.AddCommand(newTransferCmd(storeJira, true), newTransferCmd(storeTempo, true))
+	copyCmd.AddCommand(newTransferCmd(storeJira, false), newTransferCmd(storeTempo, false))
+
+	for _, cmd := range []*cobra.Command{moveCmd, copyCmd} {
+		selectionFlags(cmd)
+		cmd.PersistentFlags().StringVar(&conf.TargetTask, internal.FlagTargetTask, "", "specify the task to book the efforts on")
+		cmd.PersistentFlags().StringVar(&conf.TargetDate, internal.FlagTargetDate, "", "specify the day to book the efforts on (e.g. 2022-08-04)")
+		cmd.PersistentFlags().StringVar(&conf.Target, internal.FlagTarget, "", "specify the store to book the efforts in (jira or tempo), defaults to the source")
+	}
+}
+
+var moveCmd = &cobra.Command{
+	Use:     "move",
+	Aliases: []string{"mv"},
+	Short:   "Move worklog items",
+	Long:    "Move the selected worklog items to another task, another day or another store.",
+	Args:    cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		_, err = selection(cmd)
+		return err
+	},
+}
+
+var copyCmd = &cobra.Command{
+	Use:               "copy",
+	Aliases:           []string{"cp"},
+	Short:             "Copy worklog items",
+	Long:              "Copy the selected worklog items to another task, another day or another store.",
+	Args:              cobra.NoArgs,
+	PersistentPreRunE: moveCmd.PersistentPreRunE,
+}
+
+// newTransferCmd returns the command to move or copy the efforts of the store.
+func newTransferCmd(store string, move bool) *cobra.Command {
+	verb := "Copy"
+	if move {
+		verb = "Move"
+	}
+	name := map[string]string{storeJira: "Jira", storeTempo: "Tempo"}[store]
+	cmd := &cobra.Command{
+		Use:   store,
+		Short: fmt.Sprintf("%s worklog items from %s", verb, name),
+		Long:  fmt.Sprintf("%s the selected worklog items from %s. Every item keeps its duration and description.", verb, name),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selection, err := selection(cmd)
+			if err != nil {
+				return err
+			}
+			if !selection.Bounded() {
+				return fmt.Errorf("worklog ids need a day or a range (--%s, --%s)", internal.FlagDay, internal.FlagFrom)
+			}
+			var date time.Time
+			if conf.TargetDate != "" {
+				date, err = time.Parse(pkg.IsoYearMonthDay, conf.TargetDate)
+				if err != nil {
+					return fmt.Errorf("not a valid date '%s'", conf.TargetDate)
+				}
+			}
+			target := conf.Target
+			if target == "" {
+				target = store
+			}
+			if target == store && conf.TargetTask == "" && date.IsZero() {
+				return fmt.Errorf("specify another task, day or store (--%s, --%s, --%s)", internal.FlagTargetTask, internal.FlagTargetDate, internal.FlagTarget)
+			}
+			source, err := provider(store)
+			if err != nil {
+				return err
+			}
+			destination, err := provider(target)
+			if err != nil {
+				return err
+			}
+			return pkg.Transfer(source, destination, selection, pkg.Task(conf.TargetTask), date, move, cli.Confirmation(conf.Yes, conf.NoInput))
+		},
+	}
+	// The target may be Tempo for every source
+	tempoFlags(cmd)
+	return cmd
+}
+
+// provider returns the worklog of the current user inside the store.
+func provider(store string) (pkg.Provider, error) {
+	switch store {
+	case storeJira:
+		return jira.NewProvider(pkg.NewHttpClient(), conf.Server(), conf.Userinfo(), changes), nil
+	case storeTempo:
+		return tempo.NewProvider(pkg.NewHttpClient(), conf.Server(), conf.Userinfo(), conf.TempoToken, conf.TempoUrl, changes), nil
+	}
+	return nil, fmt.Errorf("unknown store '%s'", store)
+}
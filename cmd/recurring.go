@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/jira"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+func init() {
+	rootCmd.AddCommand(applyRecurringCmd)
+	applyRecurringCmd.AddCommand(applyRecurringJiraCmd)
+
+	applyRecurringCmd.PersistentFlags().StringVar(&conf.From, internal.FlagFrom, "", "specify the first day (e.g. 2022-08-01)")
+	applyRecurringCmd.PersistentFlags().StringVar(&conf.To, internal.FlagTo, "", "specify the last day (e.g. 2022-08-31), defaults to today")
+	applyRecurringCmd.PersistentFlags().BoolVar(&conf.Duration.Decimal, internal.FlagDecimal, false, "display duration as decimal hour")
+	applyRecurringCmd.MarkPersistentFlagRequired(internal.FlagFrom)
+}
+
+var applyRecurringCmd = &cobra.Command{
+	Use:   "apply-recurring",
+	Short: "Book recurring efforts",
+	Long:  "Book the recurring efforts of the configuration between both days. Holidays, absences and efforts, which are already booked, are skipped.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		if len(conf.Recurring) == 0 {
+			return fmt.Errorf("there are no recurring efforts (recurring) inside your configuration")
+		}
+		_, err = pkg.NewRecurrences(conf.Recurring)
+		return err
+	},
+}
+
+var applyRecurringJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Book recurring efforts to Jira",
+	Long:  "Book the recurring efforts of the current user to Atlassian Jira.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromDate, err := time.Parse(pkg.IsoYearMonthDay, conf.From)
+		if err != nil {
+			return fmt.Errorf("not a valid date '%s'", conf.From)
+		}
+		now := time.Now()
+		toDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		if conf.To != "" {
+			toDate, err = time.Parse(pkg.IsoYearMonthDay, conf.To)
+			if err != nil {
+				return fmt.Errorf("not a valid date '%s'", conf.To)
+			}
+		}
+		if toDate.Before(fromDate) {
+			return fmt.Errorf("the last day %s is before the first day %s", toDate.Format(pkg.IsoYearMonthDay), conf.From)
+		}
+		recurrences, err := pkg.NewRecurrences(conf.Recurring)
+		if err != nil {
+			return err
+		}
+		calendar, err := calendar()
+		if err != nil {
+			return err
+		}
+		timesheet := pkg.Occurrences(recurrences, fromDate, toDate, calendar)
+		if len(timesheet) == 0 {
+			return nil
+		}
+		bookings, err := jira.AddTimesheet(
+			pkg.NewHttpClient(),
+			conf.Server(),
+			conf.Userinfo(),
+			timesheet,
+			changes,
+		)
+		if err != nil {
+			return err
+		}
+		pkg.WriteBookings(os.Stdout, bookings, &conf.Duration)
+		return nil
+	},
+}
@@ -5,26 +5,27 @@ import (
 	"eager/pkg"
 	"eager/pkg/cli"
 	"eager/pkg/jira"
+	"eager/pkg/tempo"
+	"fmt"
 	"github.com/spf13/cobra"
 	"time"
 )
 
 func init() {
 	rootCmd.AddCommand(removeCmd)
-	removeCmd.AddCommand(removeJiraCmd)
+	removeCmd.AddCommand(removeJiraCmd, removeTempoCmd)
 
-	removeCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year")
-	removeCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month")
-	removeCmd.PersistentFlags().IntVar(&conf.Day, internal.FlagDay, time.Now().Day(), "specify the day")
-	removeCmd.PersistentFlags().StringVar(&conf.Task, internal.FlagTask, "", "specify the task")
-	removeCmd.MarkFlagRequired(internal.FlagTask)
+	selectionFlags(removeCmd)
+
+	jiraQueryFlags(removeJiraCmd)
+	tempoFlags(removeTempoCmd)
 }
 
 var removeCmd = &cobra.Command{
 	Use:     "remove",
 	Aliases: []string{"rm"},
 	Short:   "Remove worklog item",
-	Long:    "Remove a worklog item from your local store.",
+	Long:    "Remove the selected worklog items of the current user after one confirmation.",
 	Args:    cobra.NoArgs,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
@@ -32,25 +33,134 @@ var removeCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		return nil
+		_, err = selection(cmd)
+		return err
 	},
 }
 
 var removeJiraCmd = &cobra.Command{
 	Use:   "jira",
 	Short: "Remove worklog item from Jira",
-	Long:  "Remove a worklog item from Atlassian Jira.",
+	Long:  "Remove the selected worklog items from Atlassian Jira. Worklog ids need their task or a day. The Jira query restricts the issues between the first and the last day.",
 	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		jira.RemoveWorklogItem(
+	RunE: func(cmd *cobra.Command, args []string) error {
+		selection, err := jiraSelection(cmd)
+		if err != nil {
+			return err
+		}
+		return jira.RemoveWorklogItem(
+			pkg.NewHttpClient(),
+			conf.Server(),
+			conf.Userinfo(),
+			jiraQuery(),
+			selection,
+			cli.Confirmation(conf.Yes, conf.NoInput),
+			changes,
+		)
+	},
+}
+
+var removeTempoCmd = &cobra.Command{
+	Use:   "tempo",
+	Short: "Remove worklog item from Tempo",
+	Long:  "Remove the selected worklog items from Tempo Timesheets.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		selection, err := selection(cmd)
+		if err != nil {
+			return err
+		}
+		return tempo.RemoveWorklogItem(
 			pkg.NewHttpClient(),
 			conf.Server(),
 			conf.Userinfo(),
-			conf.Year,
-			time.Month(conf.Month),
-			conf.Day,
-			pkg.Task(conf.Task),
-			cli.Confirmation,
+			conf.TempoToken,
+			conf.TempoUrl,
+			selection,
+			cli.Confirmation(conf.Yes, conf.NoInput),
+			changes,
 		)
 	},
 }
+
+// selectionFlags adds the flags to select worklogs of the current user.
+func selectionFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year")
+	cmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month")
+	cmd.PersistentFlags().IntVar(&conf.Day, internal.FlagDay, time.Now().Day(), "specify the day")
+	cmd.PersistentFlags().StringVar(&conf.From, internal.FlagFrom, "", "specify the first day (e.g. 2022-08-01) instead of the day")
+	cmd.PersistentFlags().StringVar(&conf.To, internal.FlagTo, "", "specify the last day (e.g. 2022-08-05), defaults to the first day")
+	cmd.PersistentFlags().StringVar(&conf.Task, internal.FlagTask, "", "specify the task")
+	cmd.PersistentFlags().StringArrayVar(&conf.Ids, internal.FlagIds, nil, "specify the id of the worklog")
+	cmd.PersistentFlags().StringArrayVar(&conf.Filters, internal.FlagFilters, nil, "select only effort, where the key matches (key=regex, e.g. description=^Meeting)")
+}
+
+// selection returns the selected worklogs of the flags.
+// The range of days is given by the first and last day or by the day. Worklog ids without any day select every day.
+func selection(cmd *cobra.Command) (pkg.Selection, error) {
+	filters, err := pkg.Filters(conf.Filters, nil)
+	if err != nil {
+		return pkg.Selection{}, err
+	}
+	result := pkg.Selection{
+		Ids:     conf.Ids,
+		Task:    pkg.Task(conf.Task),
+		Filters: filters,
+	}
+	day := cmd.Flags().Changed(internal.FlagYear) || cmd.Flags().Changed(internal.FlagMonth) || cmd.Flags().Changed(internal.FlagDay)
+	switch {
+	case conf.From != "" || conf.To != "":
+		if day {
+			return result, fmt.Errorf("the day cannot be combined with a range (--%s, --%s)", internal.FlagFrom, internal.FlagTo)
+		}
+		if conf.From == "" {
+			return result, fmt.Errorf("the range needs a first day (--%s)", internal.FlagFrom)
+		}
+		result.From, err = time.Parse(pkg.IsoYearMonthDay, conf.From)
+		if err != nil {
+			return result, fmt.Errorf("not a valid date '%s'", conf.From)
+		}
+		result.To = result.From
+		if conf.To != "" {
+			result.To, err = time.Parse(pkg.IsoYearMonthDay, conf.To)
+			if err != nil {
+				return result, fmt.Errorf("not a valid date '%s'", conf.To)
+			}
+		}
+		if result.To.Before(result.From) {
+			return result, fmt.Errorf("the last day %s is before the first day %s", conf.To, conf.From)
+		}
+	case len(conf.Ids) > 0 && !day:
+	default:
+		result.From = time.Date(conf.Year, time.Month(conf.Month), conf.Day, 0, 0, 0, 0, time.UTC)
+		result.To = result.From
+	}
+	if len(conf.Ids) == 0 && conf.Task == "" && len(filters) == 0 && conf.From == "" && !day && !jiraQueryChanged(cmd) {
+		return result, fmt.Errorf("select the worklogs by task, id, filter, day or range (--%s, --%s, --%s, --%s, --%s)", internal.FlagTask, internal.FlagIds, internal.FlagFilters, internal.FlagDay, internal.FlagFrom)
+	}
+	return result, nil
+}
+
+// jiraQueryChanged tells, if a Jira query flag is set on the command. The configured query selects nothing on its own.
+// Only remove jira has these flags, so they never count for Tempo, move or copy.
+func jiraQueryChanged(cmd *cobra.Command) bool {
+	for _, flag := range []string{internal.FlagJql, internal.FlagJiraFilter, internal.FlagComponents, internal.FlagLabels, internal.FlagIssueTypes, internal.FlagSprints} {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// jiraSelection returns the selected worklogs of the flags for Jira.
+// Jira reads worklogs only per issue, so worklog ids need their task or a day.
+func jiraSelection(cmd *cobra.Command) (pkg.Selection, error) {
+	result, err := selection(cmd)
+	if err != nil {
+		return result, err
+	}
+	if result.Task == "" && !result.Bounded() {
+		return result, fmt.Errorf("worklog ids of Jira need their task or a day (--%s, --%s, --%s)", internal.FlagTask, internal.FlagDay, internal.FlagFrom)
+	}
+	return result, nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"github.com/spf13/cobra"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseSelection parses the selection flags of remove jira on a fresh configuration.
+func parseSelection(t *testing.T, args ...string) *cobra.Command {
+	conf = internal.Configuration{}
+	cmd := &cobra.Command{Use: "jira"}
+	selectionFlags(cmd)
+	jiraQueryFlags(cmd)
+	err := cmd.ParseFlags(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestSelection(t *testing.T) {
+	first := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		args []string
+		want pkg.Selection
+	}{
+		{name: "day", args: []string{"--task", "PROJ-1", "--year", "2022", "--month", "8", "--day", "1"}, want: pkg.Selection{Task: "PROJ-1", From: first, To: first}},
+		{name: "only day", args: []string{"--day", "3", "--month", "8", "--year", "2022"}, want: pkg.Selection{From: first.AddDate(0, 0, 2), To: first.AddDate(0, 0, 2)}},
+		{name: "range", args: []string{"--from", "2022-08-01", "--to", "2022-08-05"}, want: pkg.Selection{From: first, To: first.AddDate(0, 0, 4)}},
+		{name: "first day", args: []string{"--from", "2022-08-01"}, want: pkg.Selection{From: first, To: first}},
+		{name: "id", args: []string{"--id", "1", "--id", "2"}, want: pkg.Selection{Ids: []string{"1", "2"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := selection(parseSelection(t, test.args...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got.Filters = nil
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectionFilterAndQuery(t *testing.T) {
+	got, err := selection(parseSelection(t, "--filter", "description=^Meeting"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Filters) != 1 || !got.Bounded() {
+		t.Errorf("got %v want a filter on the current day", got)
+	}
+	got, err = selection(parseSelection(t, "--jql", "project = PROJ"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Bounded() {
+		t.Errorf("got %v want the current day", got)
+	}
+}
+
+func TestSelectionInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "nothing", args: nil, want: "select the worklogs"},
+		{name: "day and range", args: []string{"--from", "2022-08-01", "--day", "3"}, want: "cannot be combined"},
+		{name: "last day only", args: []string{"--to", "2022-08-01"}, want: "needs a first day"},
+		{name: "invalid date", args: []string{"--from", "2022-13-01"}, want: "not a valid date"},
+		{name: "reversed range", args: []string{"--from", "2022-08-05", "--to", "2022-08-01"}, want: "before the first day"},
+		{name: "invalid filter", args: []string{"--task", "PROJ-1", "--filter", "description"}, want: "key=regex"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := selection(parseSelection(t, test.args...))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got %v want %s", err, test.want)
+			}
+		})
+	}
+}
+
+func TestJiraSelection(t *testing.T) {
+	_, err := jiraSelection(parseSelection(t, "--id", "1"))
+	if err == nil || !strings.Contains(err.Error(), "need their task or a day") {
+		t.Errorf("got %v want missing task", err)
+	}
+	got, err := jiraSelection(parseSelection(t, "--id", "1", "--task", "PROJ-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := pkg.Selection{Ids: []string{"1"}, Task: "PROJ-1"}
+	got.Filters = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	_, err = jiraSelection(parseSelection(t, "--id", "1", "--day", "1"))
+	if err != nil {
+		t.Errorf("got %v want the worklog ids of the day", err)
+	}
+}
+
+func TestSelectionConfiguredQuery(t *testing.T) {
+	// A query of the configuration selects nothing on its own, e.g. for remove tempo
+	jira := parseSelection(t)
+	tempo := &cobra.Command{Use: "tempo"}
+	selectionFlags(tempo)
+	conf.Query.Jql = "project = PROJ"
+	conf.Query.Labels = []string{"billable"}
+	for _, cmd := range []*cobra.Command{jira, tempo} {
+		_, err := selection(cmd)
+		if err == nil || !strings.Contains(err.Error(), "select the worklogs") {
+			t.Errorf("got %v want nothing selected for %s", err, cmd.Use)
+		}
+	}
+}
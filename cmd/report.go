@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/report"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+const (
+	outputCsv   = "csv"
+	outputTable = "table"
+	outputHtml  = "html"
+	outputXlsx  = "xlsx"
+	outputJson  = "json"
+)
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportBcsCmd, reportJiraCmd, reportMatrixCmd)
+	reportMatrixCmd.AddCommand(reportMatrixBcsCmd, reportMatrixJiraCmd)
+
+	reportCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year to query effort for")
+	reportCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month to query effort for")
+	reportCmd.PersistentFlags().BoolVar(&conf.Duration.Empty, internal.FlagEmpty, false, "print empty durations")
+	reportCmd.PersistentFlags().BoolVar(&conf.Duration.Decimal, internal.FlagDecimal, false, "display duration as decimal hour")
+
+	reportBcsCmd.Flags().StringVar(&conf.Template, internal.FlagTemplate, report.TemplateMonthly, "specify the template file or one of the built-in templates monthly and activity")
+	reportBcsCmd.Flags().StringVar(&conf.Report, internal.FlagReport, "", "specify the name of the report")
+	reportBcsCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the oid of the project")
+	reportBcsCmd.MarkFlagRequired(internal.FlagReport)
+
+	reportJiraCmd.Flags().StringVar(&conf.Template, internal.FlagTemplate, report.TemplateMonthly, "specify the template file or one of the built-in templates monthly and activity")
+	reportJiraCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the project key")
+	reportJiraCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "show results for user (or user=id, where id is the account id)")
+	jiraQueryFlags(reportJiraCmd)
+
+	reportMatrixCmd.PersistentFlags().StringVar(&conf.Output, internal.FlagOutput, outputCsv, "specify the output format (csv, table or html)")
+	reportMatrixCmd.PersistentFlags().StringVar(&conf.Rows, internal.FlagRows, pkg.MatrixRowsTask, "specify the rows of the matrix (task or user)")
+
+	reportMatrixBcsCmd.Flags().StringVar(&conf.Report, internal.FlagReport, "", "specify the name of the report")
+	reportMatrixBcsCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the oid of the project")
+	reportMatrixBcsCmd.MarkFlagRequired(internal.FlagReport)
+
+	reportMatrixJiraCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the project key")
+	reportMatrixJiraCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "show results for user (or user=id, where id is the account id)")
+	jiraQueryFlags(reportMatrixJiraCmd)
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report worklog",
+	Long:  "Create a report of the worklog from the given store.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var reportBcsCmd = &cobra.Command{
+	Use:   "bcs",
+	Short: "Report worklog from BCS",
+	Long:  "Report your worklog data from Projektron BCS with a template.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := cmd.Parent().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return validateBcs()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := bcsTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return report.Write(os.Stdout, conf.Template, conf.Year, time.Month(conf.Month), timesheet, &conf.Duration)
+	},
+}
+
+var reportJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Report worklog from Jira",
+	Long:  "Report your worklog data from Atlassian Jira with a template.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := jiraTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return report.Write(os.Stdout, conf.Template, conf.Year, time.Month(conf.Month), timesheet, &conf.Duration)
+	},
+}
+
+var reportMatrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Report worklog as matrix",
+	Long:  "Report the worklog as matrix with one row per task or user and one column per day of the month.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := reportCmd.PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		switch conf.Output {
+		case outputCsv, outputTable, outputHtml:
+		default:
+			return fmt.Errorf("unknown output format '%s'", conf.Output)
+		}
+		return nil
+	},
+}
+
+var reportMatrixBcsCmd = &cobra.Command{
+	Use:   "bcs",
+	Short: "Report worklog matrix from BCS",
+	Long:  "Report your worklog data from Projektron BCS as matrix.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := reportMatrixCmd.PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return validateBcs()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := bcsTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return writeMatrix(timesheet)
+	},
+}
+
+var reportMatrixJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Report worklog matrix from Jira",
+	Long:  "Report your worklog data from Atlassian Jira as matrix.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := jiraTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return writeMatrix(timesheet)
+	},
+}
+
+func writeMatrix(timesheet pkg.Timesheet) error {
+	matrix, err := timesheet.Matrix(conf.Year, time.Month(conf.Month), conf.Rows)
+	if err != nil {
+		return err
+	}
+	switch conf.Output {
+	case outputTable:
+		return matrix.WriteTable(os.Stdout, &conf.Duration)
+	case outputHtml:
+		return matrix.WriteHtml(os.Stdout, &conf.Duration)
+	default:
+		return matrix.WriteCsv(os.Stdout, &conf.Duration)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"eager/internal"
+	"eager/pkg/journal"
 	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -15,6 +16,10 @@ import (
 
 var conf internal.Configuration
 
+// changes tell, how the command changes worklogs. Every change of the invocation belongs to the same batch of the journal,
+// serve and tui start a new batch for every action.
+var changes = &journal.Changes{Batch: journal.NewBatch()}
+
 func init() {
 	cobra.OnInitialize(func() {
 		conf, _ := rootCmd.PersistentFlags().GetString(internal.FlagConfiguration)
@@ -45,6 +50,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&conf.Host, internal.FlagHost, "H", "", "specify the host to use for effort query")
 	rootCmd.PersistentFlags().StringVarP(&conf.Username, internal.FlagUsername, "u", "", "specify the username to use for server authentication")
 	rootCmd.PersistentFlags().StringVarP(&conf.Password, internal.FlagPassword, "p", "", "specify the password to use for server authentication")
+	rootCmd.PersistentFlags().BoolVarP(&conf.Yes, internal.FlagYes, "y", false, "confirm every change without asking")
+	rootCmd.PersistentFlags().BoolVar(&conf.NoInput, internal.FlagNoInput, false, "fail instead of asking for a confirmation")
+	rootCmd.PersistentFlags().BoolVar(&conf.DryRun, internal.FlagDryRun, false, "print every change instead of sending it")
+	rootCmd.PersistentFlags().StringVar(&conf.Journal, internal.FlagJournal, journal.DefaultPath(), "specify the journal of every change, empty to disable it")
 	rootCmd.MarkPersistentFlagRequired(internal.FlagHost)
 }
 
@@ -84,6 +93,12 @@ var rootCmd = &cobra.Command{
 				return err
 			}
 		}
+		if conf.DryRun {
+			changes.DryRun = os.Stdout
+		}
+		if conf.Journal != "" {
+			changes.Journal = journal.New(conf.Journal)
+		}
 		// Remove required annotation if the user has that flag given with viper.
 		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
 			annotations := flag.Annotations[cobra.BashCompOneRequiredFlag]
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/jira"
+	"eager/pkg/server"
+	"eager/pkg/tempo"
+	"github.com/spf13/cobra"
+	"log"
+	"net/http"
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.AddCommand(serveJiraCmd, serveTempoCmd)
+
+	serveCmd.PersistentFlags().StringVar(&conf.Listen, internal.FlagListen, "localhost:8080", "specify the address to listen on")
+	serveCmd.PersistentFlags().StringVar(&conf.WriteToken, internal.FlagWriteToken, "", "specify the bearer token to add and remove efforts (read-only without)")
+
+	tempoFlags(serveTempoCmd)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve worklog over http",
+	Long:  "Serve the worklog of the given store with a REST API and a web dashboard.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var serveJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Serve worklog from Jira",
+	Long:  "Serve your worklog data from Atlassian Jira over http.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return serve(jira.NewProvider(pkg.NewHttpClient(), conf.Server(), conf.Userinfo(), changes))
+	},
+}
+
+var serveTempoCmd = &cobra.Command{
+	Use:   "tempo",
+	Short: "Serve worklog from Tempo",
+	Long:  "Serve your worklog data from Tempo Timesheets over http.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return serve(tempo.NewProvider(pkg.NewHttpClient(), conf.Server(), conf.Userinfo(), conf.TempoToken, conf.TempoUrl, changes))
+	},
+}
+
+func serve(provider pkg.Provider) error {
+	log.Printf("Listening on http://%s/", conf.Listen)
+	if conf.WriteToken == "" {
+		log.Printf("Serving read-only, --%s enables adding and removing efforts", internal.FlagWriteToken)
+	}
+	return http.ListenAndServe(conf.Listen, server.New(provider, conf.WriteToken))
+}
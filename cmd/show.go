@@ -5,6 +5,8 @@ import (
 	"eager/pkg"
 	"eager/pkg/bcs"
 	"eager/pkg/jira"
+	"eager/pkg/jira/model"
+	"eager/pkg/tempo"
 	"fmt"
 	"github.com/spf13/cobra"
 	"os"
@@ -13,7 +15,7 @@ import (
 
 func init() {
 	rootCmd.AddCommand(showCmd)
-	showCmd.AddCommand(showBcsCmd, showJiraCmd)
+	showCmd.AddCommand(showBcsCmd, showJiraCmd, showTempoCmd)
 
 	showCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year to query effort for")
 	showCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month to query effort for")
@@ -21,6 +23,13 @@ func init() {
 	showCmd.PersistentFlags().BoolVar(&conf.Duration.Empty, internal.FlagEmpty, false, "print empty durations during summary")
 	showCmd.PersistentFlags().BoolVar(&conf.Duration.Decimal, internal.FlagDecimal, false, "display duration as decimal hour")
 	showCmd.PersistentFlags().BoolVar(&conf.Duration.Negate, internal.FlagNegate, false, "negate decimal duration")
+	showCmd.PersistentFlags().StringVar(&conf.Output, internal.FlagOutput, outputCsv, "specify the output format (csv or xlsx)")
+	showCmd.PersistentFlags().DurationVar(&conf.Duration.Rounding.Unit, internal.FlagRound, 0, "round durations to a multiple of the given unit (e.g. 6m, 15m or 30m)")
+	showCmd.PersistentFlags().StringVar(&conf.Duration.Rounding.Mode, internal.FlagRoundMode, pkg.RoundNearest, "specify the rounding mode (up, down or nearest)")
+	showCmd.PersistentFlags().StringVar(&conf.Duration.Rounding.Scope, internal.FlagRoundScope, pkg.RoundEntry, "specify the rounding scope (entry or day)")
+	showCmd.PersistentFlags().StringSliceVar(&conf.Duration.GroupBy, internal.FlagGroupBy, nil, "sum effort per group of user, project, task, day, week, month, description or attribute")
+	showCmd.PersistentFlags().StringArrayVar(&conf.Filters, internal.FlagFilters, nil, "show only effort, where the group by key or attribute matches (key=regex)")
+	showCmd.PersistentFlags().StringArrayVar(&conf.Duration.Columns, internal.FlagColumns, nil, "add a column for the attribute")
 
 	showBcsCmd.Flags().StringVar(&conf.Report, internal.FlagReport, "", "specify the name of the report")
 	showBcsCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the oid of the project")
@@ -28,6 +37,13 @@ func init() {
 
 	showJiraCmd.Flags().StringArrayVar(&conf.Projects, internal.FlagProjects, nil, "specify the project key")
 	showJiraCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "show results for user (or user=id, where id is the account id)")
+	jiraQueryFlags(showJiraCmd)
+	showJiraCmd.Flags().StringArrayVar(&conf.Fields, internal.FlagFields, nil, "specify an issue field to keep as attribute (or name=field)")
+	showJiraCmd.Flags().BoolVar(&conf.Map, internal.FlagMap, false, "map project and task with the mapping configuration")
+
+	showTempoCmd.Flags().StringArrayVar(&conf.Users, internal.FlagUsers, nil, "show results for user (or user=id, where id is the account id)")
+	showTempoCmd.Flags().StringArrayVar(&conf.Fields, internal.FlagFields, nil, "specify a work attribute key or billable to use as attribute (or name=key)")
+	tempoFlags(showTempoCmd)
 }
 
 var showCmd = &cobra.Command{
@@ -47,6 +63,54 @@ var showCmd = &cobra.Command{
 		if !conf.Duration.Decimal && conf.Duration.Negate {
 			return fmt.Errorf("negative durations (--%s) are only available for decimal values (--%s)", internal.FlagNegate, internal.FlagDecimal)
 		}
+		if len(conf.Duration.GroupBy) > 0 && conf.Duration.Summarize {
+			return fmt.Errorf("groups (--%s) cannot be combined with summaries (--%s)", internal.FlagGroupBy, internal.FlagSummarize)
+		}
+		attributes := pkg.AttributeNames(pkg.Attributes(conf.Fields))
+		err = pkg.ValidateGroupBy(conf.Duration.GroupBy, attributes)
+		if err != nil {
+			return err
+		}
+		_, err = pkg.Filters(conf.Filters, attributes)
+		if err != nil {
+			return err
+		}
+		for _, column := range conf.Duration.Columns {
+			if !contains(attributes, column) {
+				return fmt.Errorf("unknown attribute '%s'", column)
+			}
+		}
+		if len(conf.Duration.Columns) > 0 && (conf.Duration.Summarize || len(conf.Duration.GroupBy) > 0) {
+			return fmt.Errorf("columns (--%s) cannot be combined with summaries or groups", internal.FlagColumns)
+		}
+		err = validateRounding()
+		if err != nil {
+			return err
+		}
+		if conf.Map {
+			_, err = pkg.NewMapping(conf.Mapping, attributes)
+			if err != nil {
+				return err
+			}
+		}
+		if conf.Duration.Rounding.Unit > 0 && len(conf.Duration.GroupBy) > 0 {
+			return fmt.Errorf("rounding (--%s) cannot be combined with groups (--%s)", internal.FlagRound, internal.FlagGroupBy)
+		}
+		switch conf.Output {
+		case outputCsv:
+		case outputXlsx:
+			if len(conf.Duration.GroupBy) > 0 {
+				return fmt.Errorf("groups (--%s) are only available for csv output", internal.FlagGroupBy)
+			}
+			if conf.Duration.Rounding.Unit > 0 {
+				return fmt.Errorf("rounding (--%s) is only available for csv output", internal.FlagRound)
+			}
+			if len(conf.Duration.Columns) > 0 {
+				return fmt.Errorf("columns (--%s) are only available for csv output", internal.FlagColumns)
+			}
+		default:
+			return fmt.Errorf("unknown output format '%s'", conf.Output)
+		}
 		return nil
 	},
 }
@@ -61,32 +125,14 @@ var showBcsCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if conf.Projects != nil && len(conf.Projects) > 1 {
-			return fmt.Errorf("only one project allowed")
-		}
-		return nil
+		return validateBcs()
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		if conf.Projects == nil || len(conf.Projects) == 0 {
-			bcs.GetTimesheet(
-				pkg.NewHttpClient(),
-				conf.Server(),
-				conf.Userinfo(),
-				conf.Year,
-				time.Month(conf.Month),
-				conf.Report,
-			).Print(os.Stdout, false, &conf.Duration)
-		} else {
-			bcs.GetBulkTimesheet(
-				pkg.NewHttpClient(),
-				conf.Server(),
-				conf.Userinfo(),
-				conf.Year,
-				time.Month(conf.Month),
-				pkg.Projects(conf.Projects),
-				conf.Report,
-			).Print(os.Stdout, true, &conf.Duration)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := bcsTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
 		}
+		return printTimesheet(timesheet, len(conf.Projects) > 0)
 	},
 }
 
@@ -95,26 +141,189 @@ var showJiraCmd = &cobra.Command{
 	Short: "Show worklog from Jira",
 	Long:  "Show your worklog data from Atlassian Jira.",
 	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		if conf.Users == nil || len(conf.Users) == 0 {
-			jira.GetTimesheet(
-				pkg.NewHttpClient(),
-				conf.Server(),
-				conf.Userinfo(),
-				conf.Year,
-				time.Month(conf.Month),
-				pkg.Projects(conf.Projects),
-			).Print(os.Stdout, false, &conf.Duration)
-		} else {
-			jira.GetBulkTimesheet(
-				pkg.NewHttpClient(),
-				conf.Server(),
-				conf.Userinfo(),
-				conf.Year,
-				time.Month(conf.Month),
-				pkg.Projects(conf.Projects),
-				pkg.Users(conf.Users),
-			).Print(os.Stdout, true, &conf.Duration)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := jiraTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
+		}
+		return printTimesheet(timesheet, len(conf.Users) > 0)
+	},
+}
+
+var showTempoCmd = &cobra.Command{
+	Use:   "tempo",
+	Short: "Show worklog from Tempo",
+	Long:  "Show your worklog data from Tempo Timesheets for Atlassian Jira.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timesheet, err := tempoTimesheet(conf.Year, time.Month(conf.Month))
+		if err != nil {
+			return err
 		}
+		return printTimesheet(timesheet, len(conf.Users) > 0)
 	},
 }
+
+func printTimesheet(timesheet pkg.Timesheet, user bool) error {
+	filters, err := pkg.Filters(conf.Filters, pkg.AttributeNames(pkg.Attributes(conf.Fields)))
+	if err != nil {
+		return err
+	}
+	timesheet = timesheet.Filter(filters)
+	if conf.Output == outputXlsx {
+		return timesheet.WriteXlsx(os.Stdout, user, &conf.Duration)
+	}
+	calendar, err := calendar()
+	if err != nil {
+		return err
+	}
+	timesheet.Print(os.Stdout, user, &conf.Duration, calendar)
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRounding() error {
+	err := pkg.ValidateRounding(conf.Duration.Rounding)
+	if err != nil {
+		return err
+	}
+	if conf.Duration.Rounding.Unit > 0 && conf.Duration.Rounding.Scope == pkg.RoundDay && !conf.Duration.Summarize {
+		return fmt.Errorf("rounding per day (--%s %s) is only available for summaries (--%s)", internal.FlagRoundScope, pkg.RoundDay, internal.FlagSummarize)
+	}
+	return nil
+}
+
+func validateBcs() error {
+	if conf.Projects != nil && len(conf.Projects) > 1 {
+		return fmt.Errorf("only one project allowed")
+	}
+	return nil
+}
+
+func bcsTimesheet(year int, month time.Month) (pkg.Timesheet, error) {
+	if conf.Projects == nil || len(conf.Projects) == 0 {
+		return bcs.GetTimesheet(
+			pkg.NewHttpClient(),
+			conf.Server(),
+			conf.Userinfo(),
+			year,
+			month,
+			conf.Report,
+			conf.Billing.Column,
+		)
+	}
+	return bcs.GetBulkTimesheet(
+		pkg.NewHttpClient(),
+		conf.Server(),
+		conf.Userinfo(),
+		year,
+		month,
+		pkg.Projects(conf.Projects),
+		conf.Report,
+		conf.Billing.Column,
+	)
+}
+
+func jiraTimesheet(year int, month time.Month) (pkg.Timesheet, error) {
+	fromDate, toDate := pkg.GetTimeRange(year, month)
+	return jiraTimesheetBetween(fromDate, toDate)
+}
+
+// jiraTimesheetBetween returns the effort between both days, excluding the last day.
+func jiraTimesheetBetween(fromDate, toDate time.Time) (pkg.Timesheet, error) {
+	var mapping *pkg.Mapping
+	if conf.Map {
+		var err error
+		mapping, err = pkg.NewMapping(conf.Mapping, pkg.AttributeNames(pkg.Attributes(conf.Fields)))
+		if err != nil {
+			return nil, fmt.Errorf("could not read mapping. %s", err.Error())
+		}
+	}
+	billing := pkg.NewBillingLabels(conf.Billing.Labels, conf.Billing.NonBillableLabels)
+	var location *time.Location
+	if conf.TimeZone != "" {
+		var err error
+		location, err = time.LoadLocation(conf.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("could not load time zone. %s", err.Error())
+		}
+	}
+	if conf.Users == nil || len(conf.Users) == 0 {
+		return jira.GetTimesheet(
+			pkg.NewHttpClient(),
+			conf.Server(),
+			conf.Userinfo(),
+			fromDate,
+			toDate,
+			pkg.Projects(conf.Projects),
+			jiraQuery(),
+			pkg.Attributes(conf.Fields),
+			mapping,
+			billing,
+			location,
+		)
+	}
+	return jira.GetBulkTimesheet(
+		pkg.NewHttpClient(),
+		conf.Server(),
+		conf.Userinfo(),
+		fromDate,
+		toDate,
+		pkg.Projects(conf.Projects),
+		pkg.Users(conf.Users),
+		jiraQuery(),
+		pkg.Attributes(conf.Fields),
+		mapping,
+		billing,
+		location,
+	)
+}
+
+func tempoTimesheet(year int, month time.Month) (pkg.Timesheet, error) {
+	return tempo.GetTimesheet(
+		pkg.NewHttpClient(),
+		conf.Server(),
+		conf.Userinfo(),
+		conf.TempoToken,
+		conf.TempoUrl,
+		year,
+		month,
+		pkg.Users(conf.Users),
+		pkg.Attributes(conf.Fields),
+	)
+}
+
+// tempoFlags adds the flags to access Tempo Cloud.
+func tempoFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&conf.TempoToken, internal.FlagTempoToken, "", "specify the api token of Tempo Cloud")
+	cmd.Flags().StringVar(&conf.TempoUrl, internal.FlagTempoUrl, tempo.CloudUrl, "specify the api url of Tempo Cloud")
+}
+
+// jiraQueryFlags adds the flags to restrict the issues of the Jira search and the time zone of the result.
+func jiraQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&conf.TimeZone, internal.FlagTimeZone, "", "specify the time zone of the days instead of the time zone of every user (e.g. Europe/Berlin)")
+	cmd.Flags().StringVar(&conf.Query.Jql, internal.FlagJql, "", "specify a JQL query to restrict the issues")
+	cmd.Flags().StringVar(&conf.Query.Filter, internal.FlagJiraFilter, "", "specify the id or name of a saved filter to restrict the issues")
+	cmd.Flags().StringArrayVar(&conf.Query.Components, internal.FlagComponents, nil, "specify the component of the issues")
+	cmd.Flags().StringArrayVar(&conf.Query.Labels, internal.FlagLabels, nil, "specify the label of the issues")
+	cmd.Flags().StringArrayVar(&conf.Query.IssueTypes, internal.FlagIssueTypes, nil, "specify the type of the issues")
+	cmd.Flags().StringArrayVar(&conf.Query.Sprints, internal.FlagSprints, nil, "specify the id or name of the sprint of the issues")
+}
+
+func jiraQuery() model.Jql {
+	return new(model.Jql).
+		Components(conf.Query.Components...).
+		Labels(conf.Query.Labels...).
+		IssueTypes(conf.Query.IssueTypes...).
+		Sprints(conf.Query.Sprints...).
+		Filter(conf.Query.Filter).
+		Query(conf.Query.Jql)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"eager/pkg/jira"
+	"eager/pkg/tempo"
+	"eager/pkg/tui"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+	tuiCmd.AddCommand(tuiJiraCmd, tuiTempoCmd)
+
+	tuiCmd.PersistentFlags().IntVar(&conf.Year, internal.FlagYear, time.Now().Year(), "specify the year to start with")
+	tuiCmd.PersistentFlags().IntVar(&conf.Month, internal.FlagMonth, int(time.Now().Month()), "specify the month to start with")
+	tuiCmd.PersistentFlags().IntVar(&conf.Day, internal.FlagDay, time.Now().Day(), "specify the day to start with")
+
+	tempoFlags(tuiTempoCmd)
+}
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Review worklog in the terminal",
+	Long:  "Review the worklog of a month inside a full-screen terminal user interface and add, edit or delete efforts in place.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var tuiJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Review worklog from Jira",
+	Long:  "Review your worklog data from Atlassian Jira in the terminal.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider := jira.NewProvider(pkg.NewHttpClient(), conf.Server(), conf.Userinfo(), changes)
+		return tui.New(provider, conf.Year, time.Month(conf.Month), conf.Day).Run(os.Stdin, os.Stdout)
+	},
+}
+
+var tuiTempoCmd = &cobra.Command{
+	Use:   "tempo",
+	Short: "Review worklog from Tempo",
+	Long:  "Review your worklog data from Tempo Timesheets in the terminal.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider := tempo.NewProvider(pkg.NewHttpClient(), conf.Server(), conf.Userinfo(), conf.TempoToken, conf.TempoUrl, changes)
+		return tui.New(provider, conf.Year, time.Month(conf.Month), conf.Day).Run(os.Stdin, os.Stdout)
+	},
+}
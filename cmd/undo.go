@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"eager/pkg"
+	"eager/pkg/jira"
+	"eager/pkg/journal"
+	"eager/pkg/tempo"
+	"fmt"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+func init() {
+	rootCmd.AddCommand(undoCmd, historyCmd)
+	undoCmd.AddCommand(undoJiraCmd, undoTempoCmd)
+
+	tempoFlags(undoTempoCmd)
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo a change of the worklog",
+	Long:  "Reverse every change of the last command or the change with the given id of the journal.",
+	Args:  cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Call to root persistent pre run necessary. See https://github.com/spf13/cobra/issues/216
+		err := cmd.Root().PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var undoJiraCmd = &cobra.Command{
+	Use:   "jira [id]",
+	Short: "Undo a change of the Jira worklog",
+	Long:  "Reverse every change of the last command or the change with the given id inside Atlassian Jira.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := undoId(args)
+		if err != nil {
+			return err
+		}
+		entries, err := jira.Undo(pkg.NewHttpClient(), conf.Server(), conf.Userinfo(), changes, id)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			fmt.Printf("Reversed %s\n", formatEntry(entry, ""))
+		}
+		return nil
+	},
+}
+
+var undoTempoCmd = &cobra.Command{
+	Use:   "tempo [id]",
+	Short: "Undo a change of the Tempo worklog",
+	Long:  "Reverse every change of the last command or the change with the given id inside Tempo Timesheets.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := undoId(args)
+		if err != nil {
+			return err
+		}
+		entries, err := tempo.Undo(pkg.NewHttpClient(), conf.Server(), conf.Userinfo(), conf.TempoToken, conf.TempoUrl, changes, id)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			fmt.Printf("Reversed %s\n", formatEntry(entry, ""))
+		}
+		return nil
+	},
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the journal",
+	Long:  "Show every change of the worklogs on the host as $ID;$TIME;$STORE;$OPERATION;$TASK;$START;$DURATION;$DESCRIPTION;$STATUS.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if changes.Journal == nil {
+			return fmt.Errorf("there is no journal")
+		}
+		entries, err := changes.Journal.Entries()
+		if err != nil {
+			return err
+		}
+		server := conf.Server().String()
+		for _, entry := range entries {
+			if entry.Server != server {
+				continue
+			}
+			var status string
+			if entry.Undo != 0 {
+				status = fmt.Sprintf("undo of %d", entry.Undo)
+			}
+			if undo := journal.UndoneBy(entries, entry.Id); undo != 0 {
+				status = fmt.Sprintf("undone by %d", undo)
+			}
+			fmt.Println(formatEntry(entry, status))
+		}
+		return nil
+	},
+}
+
+// undoId returns the id of the entry to undo or 0 for the changes of the last command.
+func undoId(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("not a valid id '%s'", args[0])
+	}
+	return id, nil
+}
+
+func formatEntry(entry journal.Entry, status string) string {
+	return fmt.Sprintf("%d;%s;%s;%s;%s;%s;%s;%s;%s",
+		entry.Id,
+		entry.Time.Format(pkg.IsoDateTime),
+		entry.Store,
+		entry.Operation,
+		entry.Issue,
+		entry.Start.Format(pkg.IsoDateTime),
+		entry.Duration,
+		entry.Description,
+		status,
+	)
+}
@@ -11,6 +11,7 @@ require (
 	github.com/spf13/viper v1.12.0
 	github.com/testcontainers/testcontainers-go v0.13.0
 	golang.org/x/net v0.0.0-20220809184613-07c6da5e1ced
+	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211
 	gopkg.in/headzoo/surf.v1 v1.0.1
 )
 
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"net/url"
+	"time"
 )
 
 const (
@@ -22,6 +23,44 @@ const (
 	FlagMonth         = "month"
 	FlagDay           = "day"
 	FlagTask          = "task"
+	FlagOutput        = "output"
+	FlagRows          = "rows"
+	FlagGroupBy       = "group-by"
+	FlagTemplate      = "template"
+	FlagPeriod        = "period"
+	FlagThreshold     = "threshold"
+	FlagNotify        = "notify"
+	FlagRound         = "round"
+	FlagRoundMode     = "round-mode"
+	FlagRoundScope    = "round-scope"
+	FlagDryRun        = "dry-run"
+	FlagMap           = "map"
+	FlagFields        = "field"
+	FlagFilters       = "filter"
+	FlagColumns       = "column"
+	FlagJql           = "jql"
+	FlagJiraFilter    = "jira-filter"
+	FlagComponents    = "component"
+	FlagLabels        = "label"
+	FlagIssueTypes    = "issue-type"
+	FlagSprints       = "sprint"
+	FlagTimeZone      = "timezone"
+	FlagTempoToken    = "tempo-token"
+	FlagTempoUrl      = "tempo-url"
+	FlagBillable      = "billable"
+	FlagAttributes    = "attribute"
+	FlagListen        = "listen"
+	FlagWriteToken    = "write-token"
+	FlagYes           = "yes"
+	FlagNoInput       = "no-input"
+	FlagJournal       = "journal"
+	FlagFrom          = "from"
+	FlagTo            = "to"
+	FlagIds           = "id"
+	FlagTarget        = "target"
+	FlagTargetTask    = "target-task"
+	FlagTargetDate    = "target-date"
+	FlagWeek          = "week"
 )
 
 type Configuration struct {
@@ -34,18 +73,163 @@ type Configuration struct {
 	Users               []string        `mapstructure:"users"`
 	Report              string          `mapstructure:"report"`
 	Duration            DurationOptions `mapstructure:",squash"`
+	Output              string          `mapstructure:"output"`
+	Rows                string          `mapstructure:"rows"`
+	Template            string          `mapstructure:"template"`
+	Period              string          `mapstructure:"period"`
+	WorkingTimes        []WorkingTime   `mapstructure:"worktime"`
+	Calendar            Calendar        `mapstructure:"calendar"`
+	Check               *Check          `mapstructure:"check"`
+	Threshold           time.Duration   `mapstructure:"threshold"`
+	Notify              string          `mapstructure:"notify"`
+	NotifyCommand       []string        `mapstructure:"notify-command"`
+	Import              Import          `mapstructure:"import"`
+	DryRun              bool            `mapstructure:"dry-run"`
+	Mapping             Mapping         `mapstructure:"mapping"`
+	Map                 bool            `mapstructure:"map"`
+	Fields              []string        `mapstructure:"fields"`
+	Filters             []string        `mapstructure:"filters"`
+	Query               Query           `mapstructure:",squash"`
+	TimeZone            string          `mapstructure:"timezone"`
+	TempoToken          string          `mapstructure:"tempo-token"`
+	TempoUrl            string          `mapstructure:"tempo-url"`
+	Rates               []Rate          `mapstructure:"rates"`
+	Billing             Billing         `mapstructure:"billing"`
+	Listen              string          `mapstructure:"listen"`
+	WriteToken          string          `mapstructure:"write-token"`
+	Yes                 bool            `mapstructure:"yes"`
+	NoInput             bool            `mapstructure:"no-input"`
+	Journal             string          `mapstructure:"journal"`
+	Recurring           []Recurrence    `mapstructure:"recurring"`
 	// These items make no sense to have inside a configuration file
-	Year  int
-	Month int
-	Day   int
-	Task  string
+	Year       int
+	Month      int
+	Day        int
+	Task       string
+	Billable   time.Duration
+	Attributes []string
+	From       string
+	To         string
+	Ids        []string
+	Target     string
+	TargetTask string
+	TargetDate string
+	Week       string
 }
 
 type DurationOptions struct {
-	Summarize bool `mapstructure:"summarize"`
-	Empty     bool `mapstructure:"empty"`
-	Decimal   bool `mapstructure:"decimal"`
-	Negate    bool `mapstructure:"negate"`
+	Summarize bool     `mapstructure:"summarize"`
+	Empty     bool     `mapstructure:"empty"`
+	Decimal   bool     `mapstructure:"decimal"`
+	Negate    bool     `mapstructure:"negate"`
+	GroupBy   []string `mapstructure:"group-by"`
+	Rounding  Rounding `mapstructure:",squash"`
+	Columns   []string `mapstructure:"columns"`
+}
+
+// Rounding of durations to a multiple of the unit, either per entry or per day.
+// Without unit, durations are not rounded.
+type Rounding struct {
+	Unit  time.Duration `mapstructure:"round"`
+	Mode  string        `mapstructure:"round-mode"`
+	Scope string        `mapstructure:"round-scope"`
+}
+
+// WorkingTime is the expected effort of a user.
+// Either daily or weekly hours are distributed over the weekdays, durations per weekday take precedence.
+// An empty user defines the working time of every user without own working time.
+type WorkingTime struct {
+	User      string                   `mapstructure:"user"`
+	Daily     time.Duration            `mapstructure:"daily"`
+	Weekly    time.Duration            `mapstructure:"weekly"`
+	Weekdays  []string                 `mapstructure:"weekdays"`
+	Days      map[string]time.Duration `mapstructure:"days"`
+	Carryover time.Duration            `mapstructure:"carryover"`
+	Since     string                   `mapstructure:"since"`
+}
+
+// Recurrence is an effort, that is booked on every given weekday.
+// Without weekdays, the effort recurs from Monday to Friday. Since and until restrict the days, if they are given.
+type Recurrence struct {
+	Task        string        `mapstructure:"task"`
+	Duration    time.Duration `mapstructure:"duration"`
+	Description string        `mapstructure:"description"`
+	Weekdays    []string      `mapstructure:"weekdays"`
+	Since       string        `mapstructure:"since"`
+	Until       string        `mapstructure:"until"`
+}
+
+// Calendar contains the public holidays of the region and the absences of the users.
+// Absences are booked on the task for their kind.
+type Calendar struct {
+	Region   string            `mapstructure:"region"`
+	Absences []AbsenceFile     `mapstructure:"absences"`
+	Tasks    map[string]string `mapstructure:"tasks"`
+}
+
+// AbsenceFile is an iCalendar file with the absences of a user.
+// An empty user is the current user.
+type AbsenceFile struct {
+	User string `mapstructure:"user"`
+	File string `mapstructure:"file"`
+	Kind string `mapstructure:"kind"`
+}
+
+// Check contains the rules of the working time law.
+// Without check configuration, the rules of the German working time law apply.
+type Check struct {
+	MaxDaily   time.Duration `mapstructure:"max-daily"`
+	Breaks     []Break       `mapstructure:"breaks"`
+	MinBreak   time.Duration `mapstructure:"min-break"`
+	MinRest    time.Duration `mapstructure:"min-rest"`
+	NoSundays  bool          `mapstructure:"no-sundays"`
+	NoHolidays bool          `mapstructure:"no-holidays"`
+}
+
+// Break is the required pause after the given effort of a day.
+type Break struct {
+	After    time.Duration `mapstructure:"after"`
+	Duration time.Duration `mapstructure:"duration"`
+}
+
+// Query restricts the issues of the Jira search. Every condition is combined with AND.
+type Query struct {
+	Jql        string   `mapstructure:"jql"`
+	Filter     string   `mapstructure:"jira-filter"`
+	Components []string `mapstructure:"components"`
+	Labels     []string `mapstructure:"labels"`
+	IssueTypes []string `mapstructure:"issue-types"`
+	Sprints    []string `mapstructure:"sprints"`
+}
+
+// Import describes the columns of a csv file to import.
+type Import struct {
+	Columns    []string `mapstructure:"columns"`
+	Header     bool     `mapstructure:"header"`
+	DateFormat string   `mapstructure:"date-format"`
+}
+
+// Mapping maps issues to a project and task.
+// The keys of the table are issue, epic or project keys.
+// EpicLink is the field of the epic, if the epic is not the parent of the issue, e.g. the custom field Epic Link of Jira Server.
+type Mapping struct {
+	Table    map[string]MappingTarget `mapstructure:"table"`
+	Rules    []MappingRule            `mapstructure:"rules"`
+	EpicLink string                   `mapstructure:"epic-link"`
+}
+
+type MappingTarget struct {
+	Project string `mapstructure:"project"`
+	Task    string `mapstructure:"task"`
+}
+
+// MappingRule matches the values of a field with either a regular expression or a wildcard pattern.
+type MappingRule struct {
+	Field    string `mapstructure:"field"`
+	Regex    string `mapstructure:"regex"`
+	Wildcard string `mapstructure:"wildcard"`
+	Project  string `mapstructure:"project"`
+	Task     string `mapstructure:"task"`
 }
 
 func (c *Configuration) Server() *url.URL {
@@ -68,3 +252,23 @@ func (c *Configuration) Userinfo() *url.Userinfo {
 	}
 	return nil
 }
+
+// Rate is the hourly rate for the efforts of the user, project, task or customer. Empty keys match every effort.
+type Rate struct {
+	User     string  `mapstructure:"user"`
+	Project  string  `mapstructure:"project"`
+	Task     string  `mapstructure:"task"`
+	Customer string  `mapstructure:"customer"`
+	Rate     float64 `mapstructure:"rate"`
+}
+
+// Billing defines the billing status of the efforts and the currency of the invoice.
+type Billing struct {
+	// Labels of Jira issues with billable efforts
+	Labels []string `mapstructure:"labels"`
+	// NonBillableLabels of Jira issues with non-billable efforts
+	NonBillableLabels []string `mapstructure:"non-billable-labels"`
+	// Column tells, if the effort filter of BCS has a billable column after the duration
+	Column   bool   `mapstructure:"column"`
+	Currency string `mapstructure:"currency"`
+}
@@ -0,0 +1,23 @@
+package fake
+
+import (
+	"eager/pkg"
+	"eager/pkg/jira/model"
+)
+
+// Issue is an issue of the project PROJ.
+type Issue struct {
+	IssueId  string
+	IssueKey model.IssueKey
+	// Fields are the attribute values by field
+	Fields map[string]string
+}
+
+func (issue Issue) Id() string                    { return issue.IssueId }
+func (issue Issue) Project() pkg.Project          { return "PROJ" }
+func (issue Issue) Key() model.IssueKey           { return issue.IssueKey }
+func (issue Issue) Epic() model.IssueKey          { return "" }
+func (issue Issue) Components() []string          { return nil }
+func (issue Issue) Labels() []string              { return nil }
+func (issue Issue) Attribute(field string) string { return issue.Fields[field] }
+func (issue Issue) String() string                { return string(issue.IssueKey) }
@@ -0,0 +1,73 @@
+// Package fake contains the test doubles, that are shared by the tests of several packages.
+package fake
+
+import (
+	"eager/pkg"
+	"fmt"
+	"time"
+)
+
+// Provider keeps the efforts in memory.
+type Provider struct {
+	Efforts pkg.Timesheet
+	// Loaded are the months of every call of Timesheet
+	Loaded []time.Month
+	// Added and Removed are the efforts of every call of Add and Remove
+	Added   pkg.Timesheet
+	Removed pkg.Timesheet
+	// FailTask is a task, that cannot be added
+	FailTask pkg.Task
+	// Err is returned by Timesheet, if it is set
+	Err error
+	// last is the last id of an added effort
+	last int
+}
+
+func (provider *Provider) Timesheet(year int, month time.Month) (pkg.Timesheet, error) {
+	provider.Loaded = append(provider.Loaded, month)
+	if provider.Err != nil {
+		return nil, provider.Err
+	}
+	var result pkg.Timesheet
+	for _, effort := range provider.Efforts {
+		if effort.Date.Year() == year && effort.Date.Month() == month {
+			result = append(result, effort)
+		}
+	}
+	return result, nil
+}
+
+// Add keeps the effort with the next id, that is not used by another effort.
+func (provider *Provider) Add(effort pkg.Effort) (string, error) {
+	if provider.FailTask != "" && effort.Task == provider.FailTask {
+		return "", fmt.Errorf("could not add effort of %s", effort.Task)
+	}
+	provider.Added = append(provider.Added, effort)
+	provider.last++
+	for provider.used(fmt.Sprint(provider.last)) {
+		provider.last++
+	}
+	effort.Id = fmt.Sprint(provider.last)
+	provider.Efforts = append(provider.Efforts, effort)
+	return effort.Id, nil
+}
+
+func (provider *Provider) Remove(effort pkg.Effort) error {
+	for i, e := range provider.Efforts {
+		if e.Id == effort.Id {
+			provider.Removed = append(provider.Removed, effort)
+			provider.Efforts = append(provider.Efforts[:i:i], provider.Efforts[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("found no effort %s", effort.Id)
+}
+
+func (provider *Provider) used(id string) bool {
+	for _, effort := range provider.Efforts {
+		if effort.Id == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package pkg
+
+import (
+	"eager/internal"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	GroupByUser        = "user"
+	GroupByProject     = "project"
+	GroupByTask        = "task"
+	GroupByDay         = "day"
+	GroupByWeek        = "week"
+	GroupByMonth       = "month"
+	GroupByDescription = "description"
+)
+
+var groupByKeys = map[string]func(effort Effort) string{
+	GroupByUser: func(effort Effort) string {
+		if effort.User == nil {
+			return ""
+		}
+		return effort.User.DisplayName
+	},
+	GroupByProject: func(effort Effort) string {
+		return string(effort.Project)
+	},
+	GroupByTask: func(effort Effort) string {
+		return string(effort.Task)
+	},
+	GroupByDay: func(effort Effort) string {
+		return effort.Date.Format(IsoYearMonthDay)
+	},
+	GroupByWeek: func(effort Effort) string {
+		return IsoWeek(effort.Date)
+	},
+	GroupByMonth: func(effort Effort) string {
+		return effort.Date.Format(IsoYearMonth)
+	},
+	GroupByDescription: func(effort Effort) string {
+		return string(effort.Description)
+	},
+}
+
+type Group struct {
+	Keys     []string
+	Duration time.Duration
+	// Level is the number of keys the group is built of.
+	// Subtotals have a level lower than the number of group by keys, the total has level zero.
+	Level int
+}
+
+// ValidateGroupBy checks, that every key is a group by key or one of the attributes.
+func ValidateGroupBy(groupBy []string, attributes []string) error {
+	seen := map[string]bool{}
+	for _, key := range groupBy {
+		if !validKey(key, attributes) {
+			return fmt.Errorf("cannot group by '%s'", key)
+		}
+		if seen[key] {
+			return fmt.Errorf("duplicate group by '%s'", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Aggregate sums up the effort for every combination of the given keys.
+// The result is sorted by the keys and contains a subtotal after every group of a leading key and the total at the end.
+func (ts Timesheet) Aggregate(groupBy []string) []Group {
+	sum := map[string]*Group{}
+	for _, effort := range ts {
+		keys := make([]string, len(groupBy))
+		for i, key := range groupBy {
+			keys[i] = value(key)(effort)
+		}
+		id := strings.Join(keys, "\x00")
+		group := sum[id]
+		if group == nil {
+			group = &Group{
+				Keys:  keys,
+				Level: len(groupBy),
+			}
+			sum[id] = group
+		}
+		group.Duration += effort.Duration
+	}
+	groups := make([]Group, 0, len(sum))
+	for _, group := range sum {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		for k := range groupBy {
+			if groups[i].Keys[k] != groups[j].Keys[k] {
+				return groups[i].Keys[k] < groups[j].Keys[k]
+			}
+		}
+		return false
+	})
+
+	result := make([]Group, 0, len(groups)*2)
+	subtotals := make([]Group, len(groupBy))
+	for level := range subtotals {
+		subtotals[level].Level = level
+	}
+	flush := func(level int) {
+		// Write the subtotals of all levels below the given one, innermost first
+		for l := len(groupBy) - 1; l > level; l-- {
+			if subtotals[l].Keys != nil {
+				result = append(result, subtotals[l])
+			}
+			subtotals[l] = Group{Level: l}
+		}
+	}
+	for i, group := range groups {
+		if i > 0 {
+			level := 0
+			for level < len(groupBy) && group.Keys[level] == groups[i-1].Keys[level] {
+				level++
+			}
+			flush(level)
+		}
+		result = append(result, group)
+		for level := range subtotals {
+			if subtotals[level].Keys == nil {
+				subtotals[level].Keys = append(append([]string{}, group.Keys[:level]...), make([]string, len(groupBy)-level)...)
+			}
+			subtotals[level].Duration += group.Duration
+		}
+	}
+	flush(0)
+	if len(groupBy) > 0 {
+		total := subtotals[0]
+		total.Keys = make([]string, len(groupBy))
+		result = append(result, total)
+	}
+	return result
+}
+
+func (ts Timesheet) printGroups(writer io.Writer, opts *internal.DurationOptions) {
+	csvw := csv.NewWriter(writer)
+	csvw.Comma = ';'
+
+	result := make([]string, len(opts.GroupBy)+1)
+	for _, group := range ts.Aggregate(opts.GroupBy) {
+		copy(result, group.Keys)
+		if group.Level == 0 {
+			result[0] = "Total"
+		} else if group.Level < len(opts.GroupBy) {
+			result[group.Level] = "Subtotal"
+		}
+		result[len(opts.GroupBy)] = FormatDuration(group.Duration, opts)
+		err := csvw.Write(result)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	csvw.Flush()
+}
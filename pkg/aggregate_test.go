@@ -0,0 +1,80 @@
+package pkg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAggregate(t *testing.T) {
+	day := func(day int) time.Time { return time.Date(2022, time.August, day, 0, 0, 0, 0, time.UTC) }
+	timesheet := Timesheet{
+		{Project: "B", Task: "B-1", Date: day(1), Duration: time.Hour},
+		{Project: "A", Task: "A-2", Date: day(1), Duration: 2 * time.Hour},
+		{Project: "A", Task: "A-1", Date: day(1), Duration: 30 * time.Minute},
+		{Project: "A", Task: "A-1", Date: day(2), Duration: 30 * time.Minute},
+	}
+	tests := []struct {
+		name    string
+		groupBy []string
+		want    []Group
+	}{
+		{
+			name:    "one key",
+			groupBy: []string{GroupByProject},
+			want: []Group{
+				{Keys: []string{"A"}, Duration: 3 * time.Hour, Level: 1},
+				{Keys: []string{"B"}, Duration: time.Hour, Level: 1},
+				{Keys: []string{""}, Duration: 4 * time.Hour, Level: 0},
+			},
+		},
+		{
+			name:    "subtotals",
+			groupBy: []string{GroupByProject, GroupByTask},
+			want: []Group{
+				{Keys: []string{"A", "A-1"}, Duration: time.Hour, Level: 2},
+				{Keys: []string{"A", "A-2"}, Duration: 2 * time.Hour, Level: 2},
+				{Keys: []string{"A", ""}, Duration: 3 * time.Hour, Level: 1},
+				{Keys: []string{"B", "B-1"}, Duration: time.Hour, Level: 2},
+				{Keys: []string{"B", ""}, Duration: time.Hour, Level: 1},
+				{Keys: []string{"", ""}, Duration: 4 * time.Hour, Level: 0},
+			},
+		},
+		{
+			name:    "three keys",
+			groupBy: []string{GroupByProject, GroupByTask, GroupByDay},
+			want: []Group{
+				{Keys: []string{"A", "A-1", "2022-08-01"}, Duration: 30 * time.Minute, Level: 3},
+				{Keys: []string{"A", "A-1", "2022-08-02"}, Duration: 30 * time.Minute, Level: 3},
+				{Keys: []string{"A", "A-1", ""}, Duration: time.Hour, Level: 2},
+				{Keys: []string{"A", "A-2", "2022-08-01"}, Duration: 2 * time.Hour, Level: 3},
+				{Keys: []string{"A", "A-2", ""}, Duration: 2 * time.Hour, Level: 2},
+				{Keys: []string{"A", "", ""}, Duration: 3 * time.Hour, Level: 1},
+				{Keys: []string{"B", "B-1", "2022-08-01"}, Duration: time.Hour, Level: 3},
+				{Keys: []string{"B", "B-1", ""}, Duration: time.Hour, Level: 2},
+				{Keys: []string{"B", "", ""}, Duration: time.Hour, Level: 1},
+				{Keys: []string{"", "", ""}, Duration: 4 * time.Hour, Level: 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timesheet.Aggregate(tt.groupBy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	if err := ValidateGroupBy([]string{GroupByUser, "Account"}, []string{"Account"}); err != nil {
+		t.Errorf("got %v want no error", err)
+	}
+	if err := ValidateGroupBy([]string{"unknown"}, nil); err == nil {
+		t.Errorf("got no error for an unknown key")
+	}
+	if err := ValidateGroupBy([]string{GroupByTask, GroupByTask}, nil); err == nil {
+		t.Errorf("got no error for a duplicate key")
+	}
+}
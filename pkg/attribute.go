@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Attribute is an additional field of the store, which is kept by its name inside the attributes of an effort.
+type Attribute struct {
+	Name  string
+	Field string
+}
+
+// Filter keeps only efforts, where the value of the group by key or attribute matches the pattern.
+type Filter struct {
+	Key     string
+	Pattern *regexp.Regexp
+}
+
+// Attributes parses the attributes given as field or name=field.
+func Attributes(attributes []string) []Attribute {
+	result := make([]Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		parts := strings.SplitN(attribute, "=", 2)
+		field := parts[0]
+		if len(parts) == 2 {
+			field = parts[1]
+		}
+		result = append(result, Attribute{
+			Name:  parts[0],
+			Field: field,
+		})
+	}
+	return result
+}
+
+func AttributeNames(attributes []Attribute) []string {
+	names := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		names[i] = attribute.Name
+	}
+	return names
+}
+
+// value returns the function to get the value of a group by key or, if there is none, of the attribute with the given name.
+func value(key string) func(effort Effort) string {
+	if f := groupByKeys[key]; f != nil {
+		return f
+	}
+	return func(effort Effort) string {
+		return effort.Attributes[key]
+	}
+}
+
+// validKey checks, if the key is a group by key or one of the attributes.
+func validKey(key string, attributes []string) bool {
+	if groupByKeys[key] != nil {
+		return true
+	}
+	for _, attribute := range attributes {
+		if attribute == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Filters parses the filters given as key=regex, where key is a group by key or one of the attributes.
+func Filters(filters []string, attributes []string) ([]Filter, error) {
+	result := make([]Filter, 0, len(filters))
+	for _, filter := range filters {
+		parts := strings.SplitN(filter, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("filter '%s' must be formatted as key=regex", filter)
+		}
+		if !validKey(parts[0], attributes) {
+			return nil, fmt.Errorf("cannot filter by '%s'", parts[0])
+		}
+		pattern, err := regexp.Compile(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter '%s'. %s", filter, err.Error())
+		}
+		result = append(result, Filter{
+			Key:     parts[0],
+			Pattern: pattern,
+		})
+	}
+	return result, nil
+}
+
+// Filter returns the efforts matching every filter.
+func (ts Timesheet) Filter(filters []Filter) Timesheet {
+	if len(filters) == 0 {
+		return ts
+	}
+	return ts.Select(Selection{Filters: filters})
+}
+
+// Match tells, if the value of the key matches the pattern.
+func (filter Filter) Match(effort Effort) bool {
+	return filter.Pattern.MatchString(value(filter.Key)(effort))
+}
@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterAndGroupByAttribute(t *testing.T) {
+	date := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	timesheet := Timesheet{
+		{Task: "PROJ-1", Date: date, Duration: time.Hour, Attributes: map[string]string{"cost-center": "100", "epic": "PROJ-10"}},
+		{Task: "PROJ-2", Date: date, Duration: 2 * time.Hour, Attributes: map[string]string{"cost-center": "200", "epic": "PROJ-10"}},
+		{Task: "PROJ-3", Date: date, Duration: 3 * time.Hour, Attributes: map[string]string{"cost-center": "100"}},
+	}
+	attributes := AttributeNames(Attributes([]string{"cost-center=customfield_10100", "epic"}))
+
+	filters, err := Filters([]string{"cost-center=^1", "task=PROJ-[12]"}, attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filtered := timesheet.Filter(filters); len(filtered) != 1 || filtered[0].Task != "PROJ-1" {
+		t.Errorf("got %v", filtered)
+	}
+	if _, err = Filters([]string{"unknown=x"}, attributes); err == nil {
+		t.Error("unknown filter accepted")
+	}
+
+	err = ValidateGroupBy([]string{"cost-center"}, attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := timesheet.Aggregate([]string{"cost-center"})
+	want := []Group{
+		{Keys: []string{"100"}, Duration: 4 * time.Hour, Level: 1},
+		{Keys: []string{"200"}, Duration: 2 * time.Hour, Level: 1},
+		{Duration: 6 * time.Hour},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %v want %v", groups, want)
+	}
+	for i := range want {
+		if groups[i].Duration != want[i].Duration || groups[i].Level != want[i].Level {
+			t.Errorf("got %v want %v", groups[i], want[i])
+		}
+	}
+}
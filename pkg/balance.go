@@ -0,0 +1,211 @@
+package pkg
+
+import (
+	"eager/internal"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+var periods = map[string]func(date time.Time) string{
+	PeriodDay: func(date time.Time) string {
+		return date.Format(IsoYearMonthDay)
+	},
+	PeriodWeek: IsoWeek,
+	PeriodMonth: func(date time.Time) string {
+		return date.Format(IsoYearMonth)
+	},
+}
+
+type Balance struct {
+	User     *User
+	Period   string
+	Target   time.Duration
+	Actual   time.Duration
+	Overtime time.Duration
+	// Balance is the overtime including the carry-over from previous periods
+	Balance time.Duration
+}
+
+func ValidatePeriod(period string) error {
+	if periods[period] == nil {
+		return fmt.Errorf("unknown period '%s'", period)
+	}
+	return nil
+}
+
+// Users returns every distinct user of the timesheet sorted by name.
+// A timesheet of the current user contains a single user without name.
+func (ts Timesheet) Users() []*User {
+	index := map[string]*User{}
+	for _, effort := range ts {
+		if _, ok := index[displayName(effort.User)]; !ok {
+			index[displayName(effort.User)] = effort.User
+		}
+	}
+	users := make([]*User, 0, len(index))
+	for _, user := range index {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return displayName(users[i]) < displayName(users[j])
+	})
+	return users
+}
+
+// displayName returns the name of the user or the empty name of the current user.
+func displayName(user *User) string {
+	if user == nil {
+		return ""
+	}
+	return user.DisplayName
+}
+
+// Balance compares the actual effort of every user with the target effort of the working time for every day of the given month.
+// The users of the timesheet are completed by the given users, to include users without any effort.
+// The days are summed up per period, the opening balance per user name is carried over.
+// There is no target effort for days with an absence inside the optional calendar.
+func (ts Timesheet) Balance(year int, month time.Month, period string, users []*User, workingTimes WorkingTimes, calendar Calendar, opening map[string]time.Duration) []Balance {
+	key := periods[period]
+	users = ts.withUsers(users).Users()
+	if len(users) == 0 {
+		users = []*User{nil}
+	}
+	actual := map[string]map[time.Time]time.Duration{}
+	for _, effort := range ts.summarize() {
+		name := ""
+		if effort.User != nil {
+			name = effort.User.DisplayName
+		}
+		if actual[name] == nil {
+			actual[name] = map[time.Time]time.Duration{}
+		}
+		actual[name][effort.Date] = effort.Duration
+	}
+
+	fromDate, toDate := GetTimeRange(year, month)
+	var result []Balance
+	for _, user := range users {
+		name := ""
+		if user != nil {
+			name = user.DisplayName
+		}
+		workingTime := workingTimes.Get(user)
+		balance := opening[name]
+		var current *Balance
+		for date := fromDate; date.Before(toDate); date = date.AddDate(0, 0, 1) {
+			if current == nil || current.Period != key(date) {
+				if current != nil {
+					result = append(result, *current)
+				}
+				current = &Balance{
+					User:   user,
+					Period: key(date),
+				}
+			}
+			target := workingTime.Target(date)
+			if calendar != nil && calendar.Absence(user, date) != nil {
+				target = 0
+			}
+			current.Target += target
+			current.Actual += actual[name][date]
+			current.Overtime += actual[name][date] - target
+			balance += actual[name][date] - target
+			current.Balance = balance
+		}
+		result = append(result, *current)
+	}
+	return result
+}
+
+// withUsers adds an effort without duration for every user, so that the user is part of the timesheet.
+func (ts Timesheet) withUsers(users []*User) Timesheet {
+	for _, user := range users {
+		ts = append(ts, Effort{User: user})
+	}
+	return ts
+}
+
+// Overtime returns the difference between actual and target effort per user name for the given month.
+func (ts Timesheet) Overtime(year int, month time.Month, users []*User, workingTimes WorkingTimes, calendar Calendar) map[string]time.Duration {
+	result := map[string]time.Duration{}
+	for _, balance := range ts.Balance(year, month, PeriodMonth, users, workingTimes, calendar, nil) {
+		name := ""
+		if balance.User != nil {
+			name = balance.User.DisplayName
+		}
+		result[name] += balance.Overtime
+	}
+	return result
+}
+
+// Carryover returns the opening balance per user name for the month of the first day.
+// The opening balance is the carry-over of the working time plus the overtime of every month since the start of the working time.
+// The timesheet contains the effort of these months, e.g. from WorkingTimes.Since until the first day. Users are counted once per name.
+func (ts Timesheet) Carryover(fromDate time.Time, users []*User, workingTimes WorkingTimes, calendar Calendar) map[string]time.Duration {
+	if len(users) == 0 {
+		users = []*User{nil}
+	}
+	// A user with effort may be given once more, but the overtime is counted once per name
+	unique := make([]*User, 0, len(users))
+	counted := map[string]bool{}
+	for _, user := range users {
+		if !counted[displayName(user)] {
+			counted[displayName(user)] = true
+			unique = append(unique, user)
+		}
+	}
+	users = unique
+	opening := map[string]time.Duration{}
+	for _, user := range users {
+		if workingTime := workingTimes.Get(user); workingTime != nil {
+			opening[displayName(user)] = workingTime.Carryover
+		}
+	}
+	for date := workingTimes.Since(fromDate); date.Before(fromDate); date = date.AddDate(0, 1, 0) {
+		overtime := ts.Overtime(date.Year(), date.Month(), users, workingTimes, calendar)
+		for _, user := range users {
+			workingTime := workingTimes.Get(user)
+			if workingTime != nil && !workingTime.Since.IsZero() && !date.Before(workingTime.Since) {
+				opening[displayName(user)] += overtime[displayName(user)]
+			}
+		}
+	}
+	return opening
+}
+
+func WriteBalance(writer io.Writer, balances []Balance, user bool, opts *internal.DurationOptions) {
+	csvw := csv.NewWriter(writer)
+	csvw.Comma = ';'
+	for _, balance := range balances {
+		var result []string
+		if user {
+			name := ""
+			if balance.User != nil {
+				name = balance.User.DisplayName
+			}
+			result = append(result, name)
+		}
+		result = append(result,
+			balance.Period,
+			FormatDuration(balance.Target, opts),
+			FormatDuration(balance.Actual, opts),
+			FormatDuration(balance.Overtime, opts),
+			FormatDuration(balance.Balance, opts),
+		)
+		err := csvw.Write(result)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	csvw.Flush()
+}
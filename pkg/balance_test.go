@@ -0,0 +1,130 @@
+package pkg
+
+import (
+	"eager/internal"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBalance(t *testing.T) {
+	day := func(month time.Month, day int) time.Time { return time.Date(2022, month, day, 0, 0, 0, 0, time.UTC) }
+	workingTimes := WorkingTimes{"": {Days: map[time.Weekday]time.Duration{
+		time.Monday: 8 * time.Hour, time.Tuesday: 8 * time.Hour, time.Wednesday: 8 * time.Hour, time.Thursday: 8 * time.Hour, time.Friday: 8 * time.Hour,
+	}}}
+	// August 2022 starts on a monday and has 23 working days
+	timesheet := Timesheet{
+		{Date: day(8, 1), Duration: 9 * time.Hour},
+		{Date: day(8, 2), Duration: 4 * time.Hour},
+		{Date: day(8, 2), Duration: 3 * time.Hour},
+		{Date: day(8, 6), Duration: 2 * time.Hour},
+	}
+	vacation := absenceCalendar{"2022-08-03": {Kind: AbsenceVacation}}
+	tests := []struct {
+		name     string
+		period   string
+		calendar Calendar
+		opening  map[string]time.Duration
+		want     []Balance
+	}{
+		{
+			name:   "month",
+			period: PeriodMonth,
+			want:   []Balance{{Period: "2022-08", Target: 184 * time.Hour, Actual: 18 * time.Hour, Overtime: -166 * time.Hour, Balance: -166 * time.Hour}},
+		},
+		{
+			name:     "month with absence and opening balance",
+			period:   PeriodMonth,
+			calendar: vacation,
+			opening:  map[string]time.Duration{"": 10 * time.Hour},
+			want:     []Balance{{Period: "2022-08", Target: 176 * time.Hour, Actual: 18 * time.Hour, Overtime: -158 * time.Hour, Balance: -148 * time.Hour}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timesheet.Balance(2022, time.August, tt.period, nil, workingTimes, tt.calendar, tt.opening)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+
+	weeks := timesheet.Balance(2022, time.August, PeriodWeek, nil, workingTimes, nil, nil)
+	if len(weeks) != 5 {
+		t.Fatalf("got %d weeks want 5", len(weeks))
+	}
+	first := Balance{Period: "2022-W31", Target: 40 * time.Hour, Actual: 18 * time.Hour, Overtime: -22 * time.Hour, Balance: -22 * time.Hour}
+	if !reflect.DeepEqual(weeks[0], first) {
+		t.Errorf("got %v want %v", weeks[0], first)
+	}
+	if weeks[1].Balance != -62*time.Hour {
+		t.Errorf("got %s want the balance carried over to the next week", weeks[1].Balance)
+	}
+
+	days := timesheet.Balance(2022, time.August, PeriodDay, nil, workingTimes, nil, nil)
+	if len(days) != 31 || days[0].Overtime != time.Hour || days[1].Overtime != -time.Hour || days[5].Overtime != 2*time.Hour {
+		t.Errorf("got %v want the overtime per day", days[:6])
+	}
+}
+
+func TestBalanceUsers(t *testing.T) {
+	jane := &User{DisplayName: "Jane Doe"}
+	john := &User{DisplayName: "John Doe"}
+	workingTimes := WorkingTimes{
+		"":         {Days: map[time.Weekday]time.Duration{time.Monday: 8 * time.Hour}},
+		"John Doe": {Days: map[time.Weekday]time.Duration{time.Monday: 4 * time.Hour}},
+	}
+	timesheet := Timesheet{{User: jane, Date: time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC), Duration: 8 * time.Hour}}
+	got := timesheet.Overtime(2022, time.August, []*User{john}, workingTimes, nil)
+	want := map[string]time.Duration{"Jane Doe": -32 * time.Hour, "John Doe": -20 * time.Hour}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestCarryover(t *testing.T) {
+	workingTimes, err := NewWorkingTimes([]internal.WorkingTime{
+		{Daily: 8 * time.Hour, Weekdays: []string{"monday"}, Carryover: 5 * time.Hour, Since: "2022-07"},
+		{User: "John Doe", Daily: 8 * time.Hour, Weekdays: []string{"monday"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jane := &User{DisplayName: "Jane Doe"}
+	john := &User{DisplayName: "John Doe"}
+	fromDate := time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)
+	if since := workingTimes.Since(fromDate); !since.Equal(time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v want 2022-07-01", since)
+	}
+	// July has 4 mondays, August has 5 mondays
+	timesheet := Timesheet{
+		{User: jane, Date: time.Date(2022, time.July, 4, 0, 0, 0, 0, time.UTC), Duration: 40 * time.Hour},
+		{User: jane, Date: time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC), Duration: 40 * time.Hour},
+		{User: john, Date: time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC), Duration: 40 * time.Hour},
+	}
+	got := timesheet.Carryover(fromDate, []*User{jane, john}, workingTimes, nil)
+	want := map[string]time.Duration{"Jane Doe": 13 * time.Hour, "John Doe": 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestCarryoverGivenUser(t *testing.T) {
+	workingTimes, err := NewWorkingTimes([]internal.WorkingTime{
+		{Daily: 8 * time.Hour, Weekdays: []string{"monday"}, Since: "2022-08"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromDate := time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)
+	// August has 5 mondays, the user of the bulk balance has effort and is given with --user too
+	timesheet := Timesheet{
+		{User: &User{DisplayName: "Jane Doe"}, Date: time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC), Duration: 38 * time.Hour},
+	}
+	users := append(timesheet.Users(), &User{DisplayName: "Jane Doe"})
+	got := timesheet.Carryover(fromDate, users, workingTimes, nil)
+	want := map[string]time.Duration{"Jane Doe": -2 * time.Hour}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
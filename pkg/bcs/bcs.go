@@ -23,15 +23,15 @@ const (
 	bcsGetProjectEffort  = "/bcs/projectdetail/efforts/display/Buchungen.csv?download=component&downloadcontent=formatted&object=efforts%2CChoices%2Ceffortlist"
 )
 
-func GetTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, report string) pkg.Timesheet {
+// GetTimesheet returns the effort of the effort filter. With billable, the filter has a billable column after the duration.
+func GetTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, report string, billable bool) (pkg.Timesheet, error) {
 	client.Jar, _ = cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
 
 	err := login(client, server, userinfo)
 	if err != nil {
-		log.Println("Login did not succeed.", err)
-		return pkg.Timesheet{}
+		return nil, fmt.Errorf("login did not succeed. %s", err.Error())
 	}
 	defer func() {
 		err = logout(client, server)
@@ -42,35 +42,33 @@ func GetTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo,
 
 	err = showEffortList(client, server, url.QueryEscape(report), month, year)
 	if err != nil {
-		log.Println("Could not show effort list.", err)
-		return pkg.Timesheet{}
+		return nil, fmt.Errorf("could not show effort list. %s", err.Error())
 	}
 
 	data, err := retrieveEffortList(client, server)
 	if err != nil {
-		log.Println("Could not retrieve effort list.", err)
-		return pkg.Timesheet{}
+		return nil, fmt.Errorf("could not retrieve effort list. %s", err.Error())
 	}
 
 	timesheet := pkg.Timesheet{}
-	spec := pkg.NewCsvSpecification().Header(true).Project(true).Task(true).Description(true).Date(true).Duration(true)
+	spec := pkg.NewCsvSpecification().Header(true).Project(true).Task(true).Description(true).Date(true).Duration(true).Billable(billable)
 	timesheet, err = timesheet.ReadCsv(data, &spec)
 	if err != nil {
-		log.Println("Could not read effort list.", err)
+		return nil, fmt.Errorf("could not read effort list. %s", err.Error())
 	}
 
-	return timesheet
+	return timesheet, nil
 }
 
-func GetBulkTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, projects []pkg.Project, report string) pkg.Timesheet {
+// GetBulkTimesheet returns the effort of the projects with the effort filter. With billable, the filter has a billable column after the duration.
+func GetBulkTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, projects []pkg.Project, report string, billable bool) (pkg.Timesheet, error) {
 	client.Jar, _ = cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
 
 	err := login(client, server, userinfo)
 	if err != nil {
-		log.Println("Login did not succeed.", err)
-		return pkg.Timesheet{}
+		return nil, fmt.Errorf("login did not succeed. %s", err.Error())
 	}
 	defer func() {
 		err = logout(client, server)
@@ -80,27 +78,25 @@ func GetBulkTimesheet(client *http.Client, server *url.URL, userinfo *url.Userin
 	}()
 
 	timesheet := pkg.Timesheet{}
-	spec := pkg.NewCsvSpecification().Header(true).User(true).Project(true).Task(true).Description(true).Date(true).Duration(true).Skip()
+	spec := pkg.NewCsvSpecification().Header(true).User(true).Project(true).Task(true).Description(true).Date(true).Duration(true).Billable(billable).Skip()
 	for _, project := range projects {
 		err = showProjectEffortList(client, server, url.QueryEscape(report), month, year, project)
 		if err != nil {
-			log.Println("Could not show effort list.", err)
-			return pkg.Timesheet{}
+			return nil, fmt.Errorf("could not show effort list. %s", err.Error())
 		}
 
 		data, err := retrieveProjectEffortList(client, server)
 		if err != nil {
-			log.Println("Could not retrieve effort list.", err)
-			return pkg.Timesheet{}
+			return nil, fmt.Errorf("could not retrieve effort list. %s", err.Error())
 		}
 
 		timesheet, err = timesheet.ReadCsv(data, &spec)
 		if err != nil {
-			log.Println("Could not read effort list.", err)
+			return nil, fmt.Errorf("could not read effort list. %s", err.Error())
 		}
 	}
 
-	return timesheet
+	return timesheet, nil
 }
 
 func login(client *http.Client, server *url.URL, auth *url.Userinfo) error {
@@ -71,6 +71,9 @@ func TestGetBcsEffort(t *testing.T) {
 		}
 	})
 
-	timesheet := GetTimesheet(client, testUrl, nil, testDate.Year(), testDate.Month(), testReport)
+	timesheet, err := GetTimesheet(client, testUrl, nil, testDate.Year(), testDate.Month(), testReport, false)
+	if err != nil {
+		t.Fatal(err)
+	}
 	assert.Equal(t, len(timesheet), 1)
 }
@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	BillingUnknown     Billing = ""
+	BillingBillable    Billing = "billable"
+	BillingNonBillable Billing = "non-billable"
+
+	// AttributeBillable is the attribute with the billable duration of an effort, if the store knows it
+	AttributeBillable = "billable"
+	// AttributeCustomer is the attribute with the customer of an effort, that is used for the rates
+	AttributeCustomer = "customer"
+)
+
+// Billing is the status of an effort for invoicing. Efforts with unknown status are billable.
+type Billing string
+
+// ParseBilling reads the billing status of a column like "yes", "no", "ja" or "nein".
+func ParseBilling(value string) Billing {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y", "ja", "j", "true", "1", "x", "billable", "abrechenbar":
+		return BillingBillable
+	case "no", "n", "nein", "false", "0", "non-billable", "nicht abrechenbar":
+		return BillingNonBillable
+	}
+	return BillingUnknown
+}
+
+// BillableDuration is the duration to invoice. It is the billable duration of the store, if it is known.
+func (effort Effort) BillableDuration() time.Duration {
+	if effort.Billing == BillingNonBillable {
+		return 0
+	}
+	if value, ok := effort.Attributes[AttributeBillable]; ok {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return effort.Duration
+}
+
+// BillingLabels sets the billing status of an effort by the labels of its issue.
+// Non-billable labels take precedence.
+type BillingLabels struct {
+	Billable    []string
+	NonBillable []string
+}
+
+// NewBillingLabels returns the labels or nil, if there are none.
+func NewBillingLabels(billable, nonBillable []string) *BillingLabels {
+	if len(billable) == 0 && len(nonBillable) == 0 {
+		return nil
+	}
+	return &BillingLabels{
+		Billable:    billable,
+		NonBillable: nonBillable,
+	}
+}
+
+// Apply sets the billing status of the effort by its labels. Without labels, the effort is unchanged.
+// If there are billable labels, efforts without any of them are non-billable, otherwise they are billable.
+func (labels *BillingLabels) Apply(effort Effort, fields MappingFields) Effort {
+	if labels == nil {
+		return effort
+	}
+	has := func(list []string) bool {
+		for _, label := range fields[MapLabel] {
+			for _, value := range list {
+				if strings.EqualFold(label, value) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	switch {
+	case has(labels.NonBillable):
+		effort.Billing = BillingNonBillable
+	case has(labels.Billable):
+		effort.Billing = BillingBillable
+	case len(labels.Billable) > 0:
+		effort.Billing = BillingNonBillable
+	default:
+		effort.Billing = BillingBillable
+	}
+	return effort
+}
@@ -0,0 +1,162 @@
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ReadWeek returns the efforts of the week starting on the monday with one effort per line like "Mon PROJ-12 1h30 fix login".
+// Every line starts with the weekday, the task and the duration, the rest is the description.
+// Empty lines and lines starting with # are skipped, lines that cannot be parsed are returned as invalid bookings.
+func ReadWeek(data []byte, monday time.Time) []Booking {
+	var bookings []Booking
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for row := 1; scanner.Scan(); row++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		effort, err := parseWeekLine(line, monday)
+		booking := Booking{Row: row, Effort: effort}
+		if err != nil {
+			booking.Status = BookingInvalid
+			booking.Message = err.Error()
+		}
+		bookings = append(bookings, booking)
+	}
+	return bookings
+}
+
+func parseWeekLine(line string, monday time.Time) (Effort, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return Effort{}, fmt.Errorf("missing weekday, task or duration")
+	}
+	effort := Effort{
+		Task:        Task(strings.ToUpper(fields[1])),
+		Description: Description(strings.Join(fields[3:], " ")),
+	}
+	day, err := ParseWeekday(fields[0])
+	if err != nil {
+		return effort, err
+	}
+	effort.Date = monday.AddDate(0, 0, (int(day)+6)%7)
+	effort.Duration, err = ParseShortDuration(fields[2])
+	if err != nil {
+		return effort, err
+	}
+	return effort, nil
+}
+
+// ParseShortDuration parses a duration like time.ParseDuration, but minutes after hours need no unit, e.g. 1h30.
+func ParseShortDuration(value string) (time.Duration, error) {
+	normalized := value
+	if strings.Contains(normalized, "h") && unicode.IsDigit(rune(normalized[len(normalized)-1])) {
+		normalized += "m"
+	}
+	duration, err := time.ParseDuration(normalized)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("not a valid duration '%s'", value)
+	}
+	return duration, nil
+}
+
+// Book adds every effort of the bookings to the provider, if all of them are valid and confirmed.
+// If the provider is a TaskValidator, every task is checked before anything is booked.
+// Efforts, which are already inside the worklog at the same day with the same duration, are skipped.
+// Booking stops at the first effort, that cannot be added.
+func Book(provider Provider, bookings []Booking, confirm ConfirmFunc) error {
+	if validator, ok := provider.(TaskValidator); ok {
+		checked := map[Task]error{}
+		for i := range bookings {
+			if bookings[i].Status == BookingInvalid {
+				continue
+			}
+			task := bookings[i].Effort.Task
+			if _, ok := checked[task]; !ok {
+				checked[task] = validator.ValidateTask(task)
+			}
+			if checked[task] != nil {
+				bookings[i].Status = BookingInvalid
+				bookings[i].Message = checked[task].Error()
+			}
+		}
+	}
+	invalid := 0
+	for _, booking := range bookings {
+		if booking.Status == BookingInvalid {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d efforts are invalid, nothing is booked", invalid, len(bookings))
+	}
+	if len(bookings) == 0 {
+		return fmt.Errorf("found no effort")
+	}
+
+	// Task, day and duration of every effort inside the worklog
+	type Key struct {
+		task     Task
+		date     string
+		duration time.Duration
+	}
+	existing := map[Key]bool{}
+	loaded := map[string]bool{}
+	var timesheet Timesheet
+	for i := range bookings {
+		effort := bookings[i].Effort
+		month := effort.Date.Format("2006-01")
+		if !loaded[month] {
+			efforts, err := provider.Timesheet(effort.Date.Year(), effort.Date.Month())
+			if err != nil {
+				return fmt.Errorf("could not get worklog. %s", err.Error())
+			}
+			for _, e := range efforts {
+				existing[Key{e.Task, e.Date.Format(IsoYearMonthDay), e.Duration}] = true
+			}
+			loaded[month] = true
+		}
+		key := Key{effort.Task, effort.Date.Format(IsoYearMonthDay), effort.Duration}
+		if existing[key] {
+			bookings[i].Status = BookingDuplicate
+			continue
+		}
+		existing[key] = true
+		bookings[i].Status = BookingPlanned
+		timesheet = append(timesheet, effort)
+	}
+	if len(timesheet) == 0 {
+		return nil
+	}
+
+	ok, err := confirm("Book these efforts", timesheet.Stringers())
+	if err == nil && !ok {
+		err = fmt.Errorf("the booking was declined, nothing is booked")
+	}
+	if err != nil {
+		for i := range bookings {
+			if bookings[i].Status == BookingPlanned {
+				bookings[i].Status = BookingDeclined
+			}
+		}
+		return err
+	}
+	for i := range bookings {
+		if bookings[i].Status != BookingPlanned {
+			continue
+		}
+		_, err = provider.Add(bookings[i].Effort)
+		if err != nil {
+			bookings[i].Status = BookingFailed
+			bookings[i].Message = err.Error()
+			return fmt.Errorf("could not book line %d, the following lines are not booked. %s", bookings[i].Row, err.Error())
+		}
+		bookings[i].Status = BookingAdded
+	}
+	return nil
+}
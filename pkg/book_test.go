@@ -0,0 +1,131 @@
+package pkg
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type validatingProvider struct {
+	fakeProvider
+	tasks map[Task]bool
+}
+
+func (provider *validatingProvider) ValidateTask(task Task) error {
+	if !provider.tasks[task] {
+		return fmt.Errorf("unknown issue %s", task)
+	}
+	return nil
+}
+
+func TestReadWeek(t *testing.T) {
+	monday := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	data := []byte("# Week 31\nMon PROJ-12 1h30 fix login\n\nfriday proj-13 45m\nSun PROJ-12\nTue PROJ-12 1h30x\n")
+	want := []Booking{
+		{Row: 2, Effort: Effort{Task: "PROJ-12", Description: "fix login", Date: monday, Duration: 90 * time.Minute}},
+		{Row: 4, Effort: Effort{Task: "PROJ-13", Date: monday.AddDate(0, 0, 4), Duration: 45 * time.Minute}},
+		{Row: 5, Status: BookingInvalid, Message: "missing weekday, task or duration"},
+		{Row: 6, Effort: Effort{Task: "PROJ-12", Date: monday.AddDate(0, 0, 1)}, Status: BookingInvalid, Message: "not a valid duration '1h30x'"},
+	}
+	got := ReadWeek(data, monday)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestParseIsoWeek(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+		err   bool
+	}{
+		{"2022-W31", time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2021-W01", time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC), false},
+		{"2020-W53", time.Date(2020, 12, 28, 0, 0, 0, 0, time.UTC), false},
+		{"2022-W53", time.Time{}, true},
+		{"2022-31", time.Time{}, true},
+		{"2022-W31x", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseIsoWeek(tt.value)
+			if (err != nil) != tt.err || !got.Equal(tt.want) {
+				t.Errorf("got %v, %v want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBook(t *testing.T) {
+	monday := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	confirm := func(string, []fmt.Stringer) (bool, error) { return true, nil }
+
+	provider := &validatingProvider{tasks: map[Task]bool{"PROJ-12": true}}
+	bookings := ReadWeek([]byte("Mon PROJ-12 1h\nTue PROJ-99 2h\n"), monday)
+	if err := Book(provider, bookings, confirm); err == nil {
+		t.Errorf("got no error for an unknown issue")
+	}
+	if len(provider.Added) != 0 {
+		t.Errorf("got %v want nothing booked", provider.Added)
+	}
+	if bookings[1].Status != BookingInvalid {
+		t.Errorf("got %v want %v", bookings[1].Status, BookingInvalid)
+	}
+
+	bookings = ReadWeek([]byte("Mon PROJ-12 1h\nTue PROJ-12 2h\n"), monday)
+	if err := Book(provider, bookings, confirm); err != nil {
+		t.Fatal(err)
+	}
+	if len(provider.Added) != 2 || bookings[0].Status != BookingAdded || bookings[1].Status != BookingAdded {
+		t.Errorf("got %v want both efforts added", bookings)
+	}
+}
+
+func TestBookTwice(t *testing.T) {
+	monday := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	confirm := func(string, []fmt.Stringer) (bool, error) { return true, nil }
+	provider := &fakeProvider{}
+	data := []byte("Mon PROJ-12 1h\nTue PROJ-12 2h\n")
+	if err := Book(provider, ReadWeek(data, monday), confirm); err != nil {
+		t.Fatal(err)
+	}
+	bookings := ReadWeek(data, monday)
+	if err := Book(provider, bookings, confirm); err != nil {
+		t.Fatal(err)
+	}
+	if len(provider.Added) != 2 || bookings[0].Status != BookingDuplicate || bookings[1].Status != BookingDuplicate {
+		t.Errorf("got %v, %v want duplicates", provider.Added, bookings)
+	}
+}
+
+func TestBookFailure(t *testing.T) {
+	monday := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	confirm := func(string, []fmt.Stringer) (bool, error) { return true, nil }
+	provider := &fakeProvider{FailTask: "PROJ-13"}
+	bookings := ReadWeek([]byte("Mon PROJ-12 1h\nTue PROJ-13 2h\nWed PROJ-12 2h\n"), monday)
+	err := Book(provider, bookings, confirm)
+	if err == nil {
+		t.Errorf("got no error for a failed effort")
+	}
+	want := []string{BookingAdded, BookingFailed, BookingPlanned}
+	for i, booking := range bookings {
+		if booking.Status != want[i] {
+			t.Errorf("got %s want %s for line %d", booking.Status, want[i], booking.Row)
+		}
+	}
+	if len(provider.Added) != 1 {
+		t.Errorf("got %v want only the first effort booked", provider.Added)
+	}
+}
+
+func TestBookDeclined(t *testing.T) {
+	monday := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	decline := func(string, []fmt.Stringer) (bool, error) { return false, nil }
+	provider := &fakeProvider{}
+	bookings := ReadWeek([]byte("Mon PROJ-12 1h\n"), monday)
+	err := Book(provider, bookings, decline)
+	if err == nil || bookings[0].Status != BookingDeclined || len(provider.Added) != 0 {
+		t.Errorf("got %v, %v, %v want a declined booking", err, bookings, provider.Added)
+	}
+}
@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"eager/internal"
+	"encoding/csv"
+	"io"
+	"log"
+	"strconv"
+)
+
+const (
+	BookingAdded     = "added"
+	BookingPlanned   = "planned"
+	BookingDuplicate = "duplicate"
+	BookingInvalid   = "invalid"
+	BookingFailed    = "failed"
+	BookingDeclined  = "declined"
+)
+
+// Booking is the result of adding an effort to a worklog.
+type Booking struct {
+	// Row is the number of the effort inside its source, starting with one
+	Row     int
+	Effort  Effort
+	Status  string
+	Message string
+}
+
+func WriteBookings(writer io.Writer, bookings []Booking, opts *internal.DurationOptions) {
+	csvw := csv.NewWriter(writer)
+	csvw.Comma = ';'
+	for _, booking := range bookings {
+		date := ""
+		if !booking.Effort.Date.IsZero() {
+			date = booking.Effort.Date.Format(IsoYearMonthDay)
+		}
+		err := csvw.Write([]string{
+			strconv.Itoa(booking.Row),
+			date,
+			string(booking.Effort.Task),
+			FormatDuration(booking.Effort.Duration, opts),
+			booking.Status,
+			booking.Message,
+		})
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	csvw.Flush()
+}
@@ -0,0 +1,30 @@
+package pkg
+
+import "time"
+
+type AbsenceKind string
+
+const (
+	AbsenceHoliday  AbsenceKind = "holiday"
+	AbsenceVacation AbsenceKind = "vacation"
+	AbsenceSick     AbsenceKind = "sick"
+)
+
+type Absence struct {
+	Date time.Time
+	Kind AbsenceKind
+	Name string
+}
+
+func (absence Absence) String() string {
+	if absence.Name == "" {
+		return string(absence.Kind)
+	}
+	return string(absence.Kind) + ": " + absence.Name
+}
+
+// Calendar knows the days a user is not expected to work.
+type Calendar interface {
+	// Absence returns the absence of the user at the given date or nil, if the user is expected to work.
+	Absence(user *User, date time.Time) *Absence
+}
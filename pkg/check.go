@@ -0,0 +1,209 @@
+package pkg
+
+import (
+	"eager/internal"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	RuleMaxDaily = "max-daily"
+	RuleBreak    = "break"
+	RuleRest     = "rest"
+	RuleSunday   = "sunday"
+	RuleHoliday  = "holiday"
+)
+
+// Rules of working time, that a timesheet is checked against.
+// Zero values disable the rule.
+type Rules struct {
+	MaxDaily time.Duration
+	Breaks   []Break
+	// MinBreak is the minimum duration of a pause to count as break
+	MinBreak   time.Duration
+	MinRest    time.Duration
+	NoSundays  bool
+	NoHolidays bool
+}
+
+// Break is the required pause, if the effort of a day exceeds the given duration.
+type Break struct {
+	After    time.Duration
+	Duration time.Duration
+}
+
+type Violation struct {
+	User    *User
+	Date    time.Time
+	Rule    string
+	Message string
+}
+
+// DefaultRules are the rules of the German working time law (Arbeitszeitgesetz).
+func DefaultRules() Rules {
+	return Rules{
+		MaxDaily: 10 * time.Hour,
+		Breaks: []Break{
+			{After: 6 * time.Hour, Duration: 30 * time.Minute},
+			{After: 9 * time.Hour, Duration: 45 * time.Minute},
+		},
+		MinBreak:   15 * time.Minute,
+		MinRest:    11 * time.Hour,
+		NoSundays:  true,
+		NoHolidays: true,
+	}
+}
+
+// NewRules returns the rules of the configuration or the default rules, if there is none.
+func NewRules(check *internal.Check) Rules {
+	if check == nil {
+		return DefaultRules()
+	}
+	rules := Rules{
+		MaxDaily:   check.MaxDaily,
+		MinBreak:   check.MinBreak,
+		MinRest:    check.MinRest,
+		NoSundays:  check.NoSundays,
+		NoHolidays: check.NoHolidays,
+	}
+	for _, b := range check.Breaks {
+		rules.Breaks = append(rules.Breaks, Break{After: b.After, Duration: b.Duration})
+	}
+	return rules
+}
+
+// Check validates the effort of every user and day against the rules.
+// Breaks and rest times are only checked for efforts with a start. Efforts starting at midnight have no start of their own,
+// because past days are booked at midnight, e.g. by import, book or absence.
+// The optional calendar is used for the holidays.
+func (ts Timesheet) Check(rules Rules, calendar Calendar) []Violation {
+	type Key struct {
+		user string
+		time.Time
+	}
+	days := map[Key]Timesheet{}
+	users := map[string]*User{}
+	for _, effort := range ts {
+		name := ""
+		if effort.User != nil {
+			name = effort.User.DisplayName
+		}
+		users[name] = effort.User
+		key := Key{name, effort.Date}
+		days[key] = append(days[key], effort)
+	}
+	keys := make([]Key, 0, len(days))
+	for key := range days {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].user != keys[j].user {
+			return keys[i].user < keys[j].user
+		}
+		return keys[i].Before(keys[j].Time)
+	})
+
+	var result []Violation
+	violation := func(key Key, rule string, format string, args ...interface{}) {
+		result = append(result, Violation{
+			User:    users[key.user],
+			Date:    key.Time,
+			Rule:    rule,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	var previous *Key
+	var previousEnd time.Time
+	for i, key := range keys {
+		day := days[key]
+		total := day.Total()
+		if total == 0 {
+			continue
+		}
+		if rules.MaxDaily > 0 && total > rules.MaxDaily {
+			violation(key, RuleMaxDaily, "effort of %s exceeds %s", total, rules.MaxDaily)
+		}
+		if rules.NoSundays && key.Weekday() == time.Sunday {
+			violation(key, RuleSunday, "effort of %s on a sunday", total)
+		}
+		if rules.NoHolidays && calendar != nil {
+			if absence := calendar.Absence(users[key.user], key.Time); absence != nil && absence.Kind == AbsenceHoliday {
+				violation(key, RuleHoliday, "effort of %s on %s", total, absence.Name)
+			}
+		}
+
+		start, end, pause, ok := day.interval(rules.MinBreak)
+		if !ok {
+			previous = nil
+			continue
+		}
+		var required time.Duration
+		for _, b := range rules.Breaks {
+			if total > b.After && b.Duration > required {
+				required = b.Duration
+			}
+		}
+		if pause < required {
+			violation(key, RuleBreak, "break of %s is less than %s for effort of %s", pause, required, total)
+		}
+		if rules.MinRest > 0 && previous != nil && previous.user == key.user && keys[i-1] == *previous {
+			if rest := start.Sub(previousEnd); rest < rules.MinRest {
+				violation(key, RuleRest, "rest of %s since %s is less than %s", rest, previousEnd.Format("2006-01-02 15:04"), rules.MinRest)
+			}
+		}
+		previous = &keys[i]
+		previousEnd = end
+	}
+	return result
+}
+
+// interval returns the first start, the last end and the sum of all pauses of at least the given minimum.
+// It is not ok, if an effort without start or starting at midnight is inside the timesheet.
+func (ts Timesheet) interval(minimum time.Duration) (time.Time, time.Time, time.Duration, bool) {
+	efforts := append(Timesheet{}, ts...)
+	for _, effort := range efforts {
+		if hour, minute, second := effort.Start.Clock(); effort.Start.IsZero() || hour+minute+second+effort.Start.Nanosecond() == 0 {
+			return time.Time{}, time.Time{}, 0, false
+		}
+	}
+	sort.Slice(efforts, func(i, j int) bool {
+		return efforts[i].Start.Before(efforts[j].Start)
+	})
+	start := efforts[0].Start
+	end := start
+	var pause time.Duration
+	for _, effort := range efforts {
+		if gap := effort.Start.Sub(end); gap >= minimum && gap > 0 {
+			pause += gap
+		}
+		if stop := effort.Start.Add(effort.Duration); stop.After(end) {
+			end = stop
+		}
+	}
+	return start, end, pause, true
+}
+
+func WriteViolations(writer io.Writer, violations []Violation, user bool) {
+	csvw := csv.NewWriter(writer)
+	csvw.Comma = ';'
+	for _, violation := range violations {
+		var result []string
+		if user {
+			name := ""
+			if violation.User != nil {
+				name = violation.User.DisplayName
+			}
+			result = append(result, name)
+		}
+		result = append(result, violation.Date.Format(IsoYearMonthDay), violation.Rule, violation.Message)
+		err := csvw.Write(result)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	csvw.Flush()
+}
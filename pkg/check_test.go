@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2022, time.August, day, hour, minute, 0, 0, time.UTC)
+	}
+	effort := func(start time.Time, duration time.Duration) Effort {
+		return Effort{
+			Date:     time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+			Start:    start,
+			Duration: duration,
+		}
+	}
+	tests := []struct {
+		name      string
+		timesheet Timesheet
+		rules     []string
+	}{
+		{name: "valid", timesheet: Timesheet{
+			effort(at(1, 8, 0), 4*time.Hour),
+			effort(at(1, 12, 30), 4*time.Hour),
+			effort(at(2, 8, 0), 6*time.Hour),
+		}},
+		{name: "max daily", timesheet: Timesheet{
+			effort(at(1, 6, 0), 5*time.Hour),
+			effort(at(1, 12, 0), 6*time.Hour),
+		}, rules: []string{RuleMaxDaily}},
+		{name: "short breaks are ignored", timesheet: Timesheet{
+			effort(at(1, 8, 0), 3*time.Hour),
+			effort(at(1, 11, 10), 2*time.Hour),
+			effort(at(1, 13, 20), 2*time.Hour),
+		}, rules: []string{RuleBreak}},
+		{name: "rest", timesheet: Timesheet{
+			effort(at(1, 14, 0), 4*time.Hour),
+			effort(at(1, 18, 30), 4*time.Hour),
+			effort(at(2, 7, 0), 2*time.Hour),
+		}, rules: []string{RuleRest}},
+		{name: "sunday", timesheet: Timesheet{
+			effort(at(7, 10, 0), time.Hour),
+		}, rules: []string{RuleSunday}},
+		{name: "without start", timesheet: Timesheet{
+			{Date: at(1, 0, 0), Duration: 9 * time.Hour},
+		}},
+		{name: "booked at midnight", timesheet: Timesheet{
+			effort(at(1, 19, 0), 4*time.Hour),
+			effort(at(2, 0, 0), 8*time.Hour),
+			effort(at(3, 0, 0), 8*time.Hour),
+			effort(at(3, 0, 0), time.Hour),
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations := test.timesheet.Check(DefaultRules(), nil)
+			if len(violations) != len(test.rules) {
+				t.Fatalf("got %v want %v", violations, test.rules)
+			}
+			for i, violation := range violations {
+				if violation.Rule != test.rules[i] {
+					t.Errorf("got %s want %s", violation.Rule, test.rules[i])
+				}
+			}
+		})
+	}
+}
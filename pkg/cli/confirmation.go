@@ -1,20 +1,41 @@
 package cli
 
 import (
-	"eager/pkg/jira/model"
+	"bufio"
+	"eager/pkg"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 )
 
-func Confirmation(worklog model.Worklog) bool {
-	var answer string
+// Confirmation lists every item of the operation and asks once.
+// With yes, the operation is confirmed without asking. With no input, the operation fails instead of asking.
+func Confirmation(yes, noInput bool) pkg.ConfirmFunc {
+	return func(operation string, items []fmt.Stringer) (bool, error) {
+		return confirm(os.Stdin, os.Stdout, yes, noInput, operation, items)
+	}
+}
 
-	fmt.Printf("Remove %s (y/N): ", worklog.String())
-	_, err := fmt.Scanln(&answer)
-	if err != nil {
-		return false
+func confirm(in io.Reader, out io.Writer, yes, noInput bool, operation string, items []fmt.Stringer) (bool, error) {
+	if len(items) == 0 {
+		return true, nil
+	}
+	fmt.Fprintf(out, "%s:\n", operation)
+	for _, item := range items {
+		fmt.Fprintf(out, "  %s\n", item.String())
+	}
+	if yes {
+		return true, nil
+	}
+	if noInput {
+		return false, fmt.Errorf("operation needs a confirmation, but input is disabled")
 	}
-	if answer == "y" {
-		return true
+	fmt.Fprintf(out, "Continue with %d worklog(s) (y/N): ", len(items))
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
 	}
-	return false
+	return false, nil
 }
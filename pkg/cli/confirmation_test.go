@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"bytes"
+	"eager/pkg"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	worklogs := []fmt.Stringer{pkg.Effort{Task: "PROJ-1"}, pkg.Effort{Task: "PROJ-2"}}
+	tests := []struct {
+		name    string
+		input   string
+		yes     bool
+		noInput bool
+		want    bool
+		err     bool
+	}{
+		{name: "accept", input: "y\n", want: true},
+		{name: "decline", input: "n\n"},
+		{name: "default", input: "\n"},
+		{name: "end of input", input: ""},
+		{name: "yes", yes: true, noInput: true, want: true},
+		{name: "no input", noInput: true, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := confirm(strings.NewReader(test.input), &out, test.yes, test.noInput, "Remove", worklogs)
+			if got != test.want || (err != nil) != test.err {
+				t.Errorf("got %v, %v want %v", got, err, test.want)
+			}
+			if strings.Count(out.String(), "\n  ") != len(worklogs) {
+				t.Errorf("got preview %q", out.String())
+			}
+		})
+	}
+}
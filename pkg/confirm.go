@@ -0,0 +1,7 @@
+package pkg
+
+import "fmt"
+
+// ConfirmFunc confirms the operation for every given item at once.
+// The operation describes what happens to the items. An error aborts the operation.
+type ConfirmFunc func(operation string, items []fmt.Stringer) (bool, error)
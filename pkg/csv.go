@@ -20,6 +20,13 @@ type CsvSpecification struct {
 	description *CsvProperty
 	date        *CsvProperty
 	duration    *CsvProperty
+	rounded     *CsvProperty
+	billable    *CsvProperty
+	calendar    Calendar
+	round       func(effort Effort) time.Duration
+	dateFormat  string
+	attributes  []*CsvProperty
+	names       []string
 }
 
 type CsvProperty struct {
@@ -37,9 +44,46 @@ func NewCsvSpecification() CsvSpecification {
 		description: newCsvProperty(),
 		date:        newCsvProperty(),
 		duration:    newCsvProperty(),
+		rounded:     newCsvProperty(),
+		billable:    newCsvProperty(),
+		dateFormat:  "02.01.2006",
 	}
 }
 
+// ParseCsvSpecification returns the specification for the given column names.
+// Every column, which is empty or named "-", is skipped.
+func ParseCsvSpecification(columns []string) (CsvSpecification, error) {
+	spec := NewCsvSpecification()
+	seen := map[string]bool{}
+	for _, column := range columns {
+		if column != "" && column != "-" && seen[column] {
+			return spec, fmt.Errorf("duplicate column '%s'", column)
+		}
+		seen[column] = true
+		switch column {
+		case "", "-":
+			spec = spec.Skip()
+		case "user":
+			spec = spec.User(true)
+		case "project":
+			spec = spec.Project(true)
+		case "task":
+			spec = spec.Task(true)
+		case "description":
+			spec = spec.Description(true)
+		case "date":
+			spec = spec.Date(true)
+		case "duration":
+			spec = spec.Duration(true)
+		case "billable":
+			spec = spec.Billable(true)
+		default:
+			return spec, fmt.Errorf("unknown column '%s'", column)
+		}
+	}
+	return spec, nil
+}
+
 func newCsvProperty() *CsvProperty {
 	return &CsvProperty{
 		enabled: false,
@@ -97,6 +141,48 @@ func (spec CsvSpecification) Duration(enable bool) CsvSpecification {
 	return spec
 }
 
+// Billable adds the billing status as column.
+func (spec CsvSpecification) Billable(enable bool) CsvSpecification {
+	if enable {
+		spec.addField(spec.billable)
+	}
+	return spec
+}
+
+// Rounded adds the rounded duration as column and a total line for the duration and the rounded duration.
+// Without function, there is no rounded duration.
+func (spec CsvSpecification) Rounded(round func(effort Effort) time.Duration) CsvSpecification {
+	if round != nil {
+		spec.round = round
+		spec.addField(spec.rounded)
+	}
+	return spec
+}
+
+// Attributes adds a column for every attribute name.
+func (spec CsvSpecification) Attributes(names []string) CsvSpecification {
+	spec.attributes = nil
+	for range names {
+		property := newCsvProperty()
+		spec.addField(property)
+		spec.attributes = append(spec.attributes, property)
+	}
+	spec.names = names
+	return spec
+}
+
+// DateFormat is the layout of the date to read.
+func (spec CsvSpecification) DateFormat(layout string) CsvSpecification {
+	spec.dateFormat = layout
+	return spec
+}
+
+// Calendar marks days with an absence inside the description, if the effort has no description.
+func (spec CsvSpecification) Calendar(calendar Calendar) CsvSpecification {
+	spec.calendar = calendar
+	return spec
+}
+
 func (spec *CsvSpecification) addField(property *CsvProperty) {
 	property.enabled = true
 	property.index = spec.fields
@@ -147,12 +233,14 @@ func (ts Timesheet) ReadCsv(data []byte, spec *CsvSpecification) (Timesheet, err
 			effort.Description = Description(row[spec.description.index])
 		}
 		if spec.date.enabled {
-			date, _ := time.Parse("02.01.2006", row[spec.date.index])
+			date, _ := time.Parse(spec.dateFormat, row[spec.date.index])
 			effort.Date = date
 		}
 		if spec.duration.enabled {
-			duration, _ := time.ParseDuration(strings.Replace(row[spec.duration.index], ",", ".", -1) + "h")
-			effort.Duration = duration
+			effort.Duration = parseDuration(row[spec.duration.index])
+		}
+		if spec.billable.enabled {
+			effort.Billing = ParseBilling(row[spec.billable.index])
 		}
 		timesheet = append(timesheet, effort)
 	}
@@ -186,12 +274,22 @@ func (ts Timesheet) WriteCsv(writer io.Writer, spec *CsvSpecification, opts *int
 		if spec.duration.enabled {
 			result[spec.duration.index] = "Duration"
 		}
+		if spec.rounded.enabled {
+			result[spec.rounded.index] = "Rounded"
+		}
+		if spec.billable.enabled {
+			result[spec.billable.index] = "Billable"
+		}
+		for i, property := range spec.attributes {
+			result[property.index] = spec.names[i]
+		}
 		err := csvw.Write(result)
 		if err != nil {
 			log.Println(err)
 		}
 	}
 
+	var total, totalRounded time.Duration
 	currentUser := ts[0].User
 	currentDate := time.Date(ts[0].Date.Year(), ts[0].Date.Month(), 1, 0, 0, 0, 0, time.UTC)
 	for _, effort := range ts {
@@ -218,22 +316,27 @@ func (ts Timesheet) WriteCsv(writer io.Writer, spec *CsvSpecification, opts *int
 		}
 		if spec.description.enabled {
 			result[spec.description.index] = string(effort.Description)
+			if effort.Description == "" {
+				result[spec.description.index] = spec.absence(effort.User, effort.Date)
+			}
 		}
 		if spec.date.enabled {
 			result[spec.date.index] = effort.Date.Format(IsoYearMonthDay)
 		}
 		if spec.duration.enabled {
-			var duration string
-			if opts.Decimal {
-				hours := effort.Duration.Hours()
-				if opts.Negate {
-					hours = -hours
-				}
-				duration = fmt.Sprintf("%.02f", hours)
-			} else {
-				duration = effort.Duration.String()
-			}
-			result[spec.duration.index] = duration
+			result[spec.duration.index] = FormatDuration(effort.Duration, opts)
+		}
+		if spec.rounded.enabled {
+			rounded := spec.round(effort)
+			result[spec.rounded.index] = FormatDuration(rounded, opts)
+			total += effort.Duration
+			totalRounded += rounded
+		}
+		if spec.billable.enabled {
+			result[spec.billable.index] = string(effort.Billing)
+		}
+		for i, property := range spec.attributes {
+			result[property.index] = effort.Attributes[spec.names[i]]
 		}
 
 		err := csvw.Write(result)
@@ -244,9 +347,54 @@ func (ts Timesheet) WriteCsv(writer io.Writer, spec *CsvSpecification, opts *int
 	if opts.Empty && currentDate.Day() > 1 {
 		emptyLinesForDaysBetween(csvw, spec, currentDate, currentDate.AddDate(0, 1, 1-currentDate.Day()), currentUser, opts.Decimal)
 	}
+	if spec.rounded.enabled {
+		result = make([]string, spec.fields)
+		if spec.date.enabled {
+			result[spec.date.index] = "Total"
+		}
+		result[spec.duration.index] = FormatDuration(total, opts)
+		result[spec.rounded.index] = FormatDuration(totalRounded, opts)
+		err := csvw.Write(result)
+		if err != nil {
+			log.Println(err)
+		}
+	}
 	csvw.Flush()
 }
 
+// parseDuration reads a duration like "1h30m" or a decimal hour like "1,5". Invalid durations are zero.
+func parseDuration(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return duration
+	}
+	duration, _ = time.ParseDuration(strings.Replace(value, ",", ".", -1) + "h")
+	return duration
+}
+
+func FormatDuration(duration time.Duration, opts *internal.DurationOptions) string {
+	if opts.Decimal {
+		hours := duration.Hours()
+		if opts.Negate {
+			hours = -hours
+		}
+		return fmt.Sprintf("%.02f", hours)
+	}
+	return duration.String()
+}
+
+func (spec *CsvSpecification) absence(user *User, date time.Time) string {
+	if spec.calendar == nil {
+		return ""
+	}
+	absence := spec.calendar.Absence(user, date)
+	if absence == nil {
+		return ""
+	}
+	return absence.String()
+}
+
 func emptyLinesForDaysBetween(csvw *csv.Writer, spec *CsvSpecification, from, to time.Time, user *User, decimal bool) {
 	result := make([]string, spec.fields)
 	if spec.user.enabled && user != nil {
@@ -262,15 +410,30 @@ func emptyLinesForDaysBetween(csvw *csv.Writer, spec *CsvSpecification, from, to
 			text = duration.String()
 		}
 		result[spec.duration.index] = text
+		if spec.rounded.enabled {
+			result[spec.rounded.index] = text
+		}
 	}
-	for i := int(to.Sub(from).Truncate(time.Hour*24).Hours() / 24); i > 0; i-- {
+	for _, empty := range emptyDaysBetween(from, to, user) {
 		if spec.date.enabled {
-			result[spec.date.index] = from.Format(IsoYearMonthDay)
+			result[spec.date.index] = empty.Date.Format(IsoYearMonthDay)
+		}
+		if spec.description.enabled {
+			result[spec.description.index] = spec.absence(user, empty.Date)
 		}
-		from = from.AddDate(0, 0, 1)
 		err := csvw.Write(result)
 		if err != nil {
 			log.Println(err)
 		}
 	}
 }
+
+// emptyDaysBetween returns an effort without duration for every day from the first day until the last day, excluding the last day.
+func emptyDaysBetween(from, to time.Time, user *User) Timesheet {
+	var result Timesheet
+	for i := int(to.Sub(from).Truncate(time.Hour*24).Hours() / 24); i > 0; i-- {
+		result = append(result, Effort{User: user, Date: from})
+		from = from.AddDate(0, 0, 1)
+	}
+	return result
+}
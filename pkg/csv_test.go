@@ -1 +1,39 @@
 package pkg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReadCsvWithSpecification(t *testing.T) {
+	spec, err := ParseCsvSpecification([]string{"date", "-", "task", "duration", "description"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec = spec.Header(true).DateFormat(IsoYearMonthDay)
+	data := []byte("Date;Ignored;Task;Duration;Description\n" +
+		"2022-08-01;x;PROJ-1;1h30m;Fix login\n" +
+		"2022-08-02;x;PROJ-2;2,5;\n")
+	timesheet, err := Timesheet{}.ReadCsv(data, &spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Timesheet{
+		{Task: "PROJ-1", Date: time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC), Duration: 90 * time.Minute, Description: "Fix login"},
+		{Task: "PROJ-2", Date: time.Date(2022, time.August, 2, 0, 0, 0, 0, time.UTC), Duration: 150 * time.Minute},
+	}
+	if len(timesheet) != len(want) {
+		t.Fatalf("got %v want %v", timesheet, want)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(timesheet[i], want[i]) {
+			t.Errorf("got %v want %v", timesheet[i], want[i])
+		}
+	}
+
+	_, err = ParseCsvSpecification([]string{"date", "date"})
+	if err == nil {
+		t.Error("duplicate column accepted")
+	}
+}
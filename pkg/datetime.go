@@ -1,10 +1,14 @@
 package pkg
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	IsoYearMonthDaySlash = "2006/01/02"
 	IsoYearMonthDay      = "2006-01-02"
+	IsoYearMonth         = "2006-01"
 	IsoDateTime          = "2006-01-02T15:04:05.000-0700"
 )
 
@@ -18,3 +22,26 @@ func GetTimeRange(year int, month time.Month) (time.Time, time.Time) {
 
 	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), time.Date(toYear, toMonth, 1, 0, 0, 0, 0, time.UTC)
 }
+
+func IsoWeek(date time.Time) string {
+	year, week := date.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// ParseIsoWeek returns the monday of the week formatted like 2022-W31.
+func ParseIsoWeek(value string) (time.Time, error) {
+	var year, week int
+	_, err := fmt.Sscanf(value, "%04d-W%02d", &year, &week)
+	if err != nil || week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("not a valid week '%s'", value)
+	}
+	// The 4th of January is always inside the first week
+	monday := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday = monday.AddDate(0, 0, -(int(monday.Weekday())+6)%7)
+	monday = monday.AddDate(0, 0, (week-1)*7)
+	// Rejects trailing characters and weeks beyond the last week of the year
+	if IsoWeek(monday) != value {
+		return time.Time{}, fmt.Errorf("not a valid week '%s'", value)
+	}
+	return monday, nil
+}
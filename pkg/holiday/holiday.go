@@ -0,0 +1,92 @@
+package holiday
+
+import (
+	"eager/pkg"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Calendar combines the public holidays of a region with the absences of every user.
+type Calendar struct {
+	rules    []rule
+	absences map[string]map[time.Time]pkg.Absence
+	mutex    sync.Mutex
+	years    map[int]map[time.Time]pkg.Absence
+}
+
+// New creates a calendar for the public holidays of the given region.
+// An empty region creates a calendar without any public holidays.
+func New(region string) (*Calendar, error) {
+	calendar := &Calendar{
+		absences: map[string]map[time.Time]pkg.Absence{},
+		years:    map[int]map[time.Time]pkg.Absence{},
+	}
+	if region == "" {
+		return calendar, nil
+	}
+	holidays, ok := rules(region)
+	if !ok {
+		return nil, fmt.Errorf("there are no holidays for region '%s'. Known regions are %v", region, Regions())
+	}
+	calendar.rules = holidays
+	return calendar, nil
+}
+
+// Add adds the absences of the user with the given name.
+func (calendar *Calendar) Add(user string, absences ...pkg.Absence) {
+	if calendar.absences[user] == nil {
+		calendar.absences[user] = map[time.Time]pkg.Absence{}
+	}
+	for _, absence := range absences {
+		calendar.absences[user][absence.Date] = absence
+	}
+}
+
+func (calendar *Calendar) Absence(user *pkg.User, date time.Time) *pkg.Absence {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	name := ""
+	if user != nil {
+		name = user.DisplayName
+	}
+	if absence, ok := calendar.absences[name][date]; ok {
+		return &absence
+	}
+	if absence, ok := calendar.holidays(date.Year())[date]; ok {
+		return &absence
+	}
+	return nil
+}
+
+// Absences returns every absence of the user in the given month, including the public holidays.
+func (calendar *Calendar) Absences(user *pkg.User, year int, month time.Month) []pkg.Absence {
+	var result []pkg.Absence
+	fromDate, toDate := pkg.GetTimeRange(year, month)
+	for date := fromDate; date.Before(toDate); date = date.AddDate(0, 0, 1) {
+		if absence := calendar.Absence(user, date); absence != nil {
+			result = append(result, *absence)
+		}
+	}
+	return result
+}
+
+func (calendar *Calendar) holidays(year int) map[time.Time]pkg.Absence {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+	holidays := calendar.years[year]
+	if holidays == nil {
+		holidays = map[time.Time]pkg.Absence{}
+		for _, holiday := range calendar.rules {
+			date, name := holiday(year)
+			if name != "" {
+				holidays[date] = pkg.Absence{
+					Date: date,
+					Kind: pkg.AbsenceHoliday,
+					Name: name,
+				}
+			}
+		}
+		calendar.years[year] = holidays
+	}
+	return holidays
+}
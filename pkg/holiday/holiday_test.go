@@ -0,0 +1,74 @@
+package holiday
+
+import (
+	"eager/pkg"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegions(t *testing.T) {
+	tests := []struct {
+		region string
+		date   time.Time
+		name   string
+	}{
+		{region: "DE", date: date(2022, time.April, 15), name: "Karfreitag"},
+		{region: "DE", date: date(2017, time.October, 31), name: "Reformationstag"},
+		{region: "DE", date: date(2022, time.October, 31)},
+		{region: "DE-BY", date: date(2022, time.June, 16), name: "Fronleichnam"},
+		{region: "DE-SN", date: date(2022, time.November, 16), name: "Buß- und Bettag"},
+		{region: "DE-BE", date: date(2018, time.March, 8)},
+		{region: "DE-BE", date: date(2019, time.March, 8), name: "Internationaler Frauentag"},
+		{region: "US", date: date(2022, time.November, 24), name: "Thanksgiving Day"},
+		{region: "GB", date: date(2022, time.May, 30), name: "Spring Bank Holiday"},
+		{region: "NL", date: date(2025, time.April, 26), name: "Koningsdag"},
+	}
+	for _, test := range tests {
+		t.Run(test.region+" "+test.date.Format(pkg.IsoYearMonthDay), func(t *testing.T) {
+			calendar, err := New(test.region)
+			if err != nil {
+				t.Fatal(err)
+			}
+			absence := calendar.Absence(nil, test.date)
+			name := ""
+			if absence != nil {
+				name = absence.Name
+			}
+			if name != test.name {
+				t.Errorf("got '%s' want '%s'", name, test.name)
+			}
+		})
+	}
+}
+
+func TestReadIcs(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20220801\r\n" +
+		"DTEND;VALUE=DATE:20220803\r\n" +
+		"SUMMARY:Summer\\, vaca\r\n tion\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20220810T090000Z\r\n" +
+		"DTEND:20220810T120000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	absences, err := ReadIcs(strings.NewReader(ics), pkg.AbsenceVacation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []pkg.Absence{
+		{Date: date(2022, time.August, 1), Kind: pkg.AbsenceVacation, Name: "Summer, vacation"},
+		{Date: date(2022, time.August, 2), Kind: pkg.AbsenceVacation, Name: "Summer, vacation"},
+		{Date: date(2022, time.August, 10), Kind: pkg.AbsenceVacation},
+	}
+	if len(absences) != len(want) {
+		t.Fatalf("got %v want %v", absences, want)
+	}
+	for i := range want {
+		if absences[i] != want[i] {
+			t.Errorf("got %v want %v", absences[i], want[i])
+		}
+	}
+}
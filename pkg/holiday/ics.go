@@ -0,0 +1,108 @@
+package holiday
+
+import (
+	"bufio"
+	"eager/pkg"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	icsDate     = "20060102"
+	icsDateTime = "20060102T150405"
+)
+
+// ReadIcs reads the events of an iCalendar file as absences of the given kind.
+// Every day between start and end of an event is an absence, the end itself is excluded for whole day events.
+func ReadIcs(reader io.Reader, kind pkg.AbsenceKind) ([]pkg.Absence, error) {
+	var result []pkg.Absence
+	var event map[string]string
+	for _, line := range unfold(reader) {
+		name, value := property(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = map[string]string{}
+		case name == "END" && value == "VEVENT":
+			absences, err := eventAbsences(event, kind)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, absences...)
+			event = nil
+		case event != nil:
+			event[name] = value
+		}
+	}
+	return result, nil
+}
+
+// unfold joins the lines, which are folded according to RFC 5545.
+func unfold(reader io.Reader) []string {
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// property splits a content line into its name without parameters and its value.
+func property(line string) (string, string) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	name := strings.SplitN(parts[0], ";", 2)[0]
+	return strings.ToUpper(name), parts[1]
+}
+
+func eventAbsences(event map[string]string, kind pkg.AbsenceKind) ([]pkg.Absence, error) {
+	start, err := icsTime(event["DTSTART"])
+	if err != nil {
+		return nil, err
+	}
+	end := start.AddDate(0, 0, 1)
+	if event["DTEND"] != "" {
+		end, err = icsTime(event["DTEND"])
+		if err != nil {
+			return nil, err
+		}
+		if end.Hour() != 0 || end.Minute() != 0 || end.Second() != 0 {
+			// Events with a time end on the given day
+			end = end.AddDate(0, 0, 1)
+		}
+	}
+	name := strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(event["SUMMARY"])
+	var result []pkg.Absence
+	for date := day(start); date.Before(day(end)); date = date.AddDate(0, 0, 1) {
+		result = append(result, pkg.Absence{
+			Date: date,
+			Kind: kind,
+			Name: name,
+		})
+	}
+	return result, nil
+}
+
+func icsTime(value string) (time.Time, error) {
+	value = strings.TrimSuffix(value, "Z")
+	if date, err := time.Parse(icsDateTime, value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(icsDate, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date '%s'", value)
+	}
+	return date, nil
+}
+
+func day(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,224 @@
+package holiday
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// rule returns the holiday of the given year. An empty name means, that there is no such holiday in that year.
+type rule func(year int) (time.Time, string)
+
+func fixed(month time.Month, day int, name string) rule {
+	return func(year int) (time.Time, string) {
+		return date(year, month, day), name
+	}
+}
+
+func since(first int, holiday rule) rule {
+	return func(year int) (time.Time, string) {
+		if year < first {
+			return time.Time{}, ""
+		}
+		return holiday(year)
+	}
+}
+
+func only(only int, holiday rule) rule {
+	return func(year int) (time.Time, string) {
+		if year != only {
+			return time.Time{}, ""
+		}
+		return holiday(year)
+	}
+}
+
+func easter(offset int, name string) rule {
+	return func(year int) (time.Time, string) {
+		return easterSunday(year).AddDate(0, 0, offset), name
+	}
+}
+
+// weekday returns the n-th weekday of the month. A negative n counts from the end of the month.
+func weekday(month time.Month, day time.Weekday, n int, name string) rule {
+	return func(year int) (time.Time, string) {
+		if n < 0 {
+			last := date(year, month+1, 0)
+			back := (int(last.Weekday()) - int(day) + 7) % 7
+			return last.AddDate(0, 0, -back+7*(n+1)), name
+		}
+		first := date(year, month, 1)
+		forward := (int(day) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, forward+7*(n-1)), name
+	}
+}
+
+// repentance is the Wednesday before November 23rd.
+func repentance(name string) rule {
+	return func(year int) (time.Time, string) {
+		day := date(year, time.November, 22)
+		back := (int(day.Weekday()) - int(time.Wednesday) + 7) % 7
+		return day.AddDate(0, 0, -back), name
+	}
+}
+
+// kingsDay is on April 27th, or the day before, if that is a Sunday.
+func kingsDay(name string) rule {
+	return func(year int) (time.Time, string) {
+		day := date(year, time.April, 27)
+		if day.Weekday() == time.Sunday {
+			day = day.AddDate(0, 0, -1)
+		}
+		return day, name
+	}
+}
+
+func lustrum(holiday rule) rule {
+	return func(year int) (time.Time, string) {
+		if year%5 != 0 {
+			return time.Time{}, ""
+		}
+		return holiday(year)
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// easterSunday calculates the date of Easter Sunday with the anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
+
+var (
+	newYear         = fixed(time.January, 1, "Neujahr")
+	epiphany        = fixed(time.January, 6, "Heilige Drei Könige")
+	womensDay       = fixed(time.March, 8, "Internationaler Frauentag")
+	goodFriday      = easter(-2, "Karfreitag")
+	easterDay       = easter(0, "Ostersonntag")
+	easterMonday    = easter(1, "Ostermontag")
+	labourDay       = fixed(time.May, 1, "Tag der Arbeit")
+	ascension       = easter(39, "Christi Himmelfahrt")
+	whitSunday      = easter(49, "Pfingstsonntag")
+	whitMonday      = easter(50, "Pfingstmontag")
+	corpusChristi   = easter(60, "Fronleichnam")
+	assumption      = fixed(time.August, 15, "Mariä Himmelfahrt")
+	childrensDay    = fixed(time.September, 20, "Weltkindertag")
+	germanUnity     = fixed(time.October, 3, "Tag der Deutschen Einheit")
+	reformation     = fixed(time.October, 31, "Reformationstag")
+	allSaints       = fixed(time.November, 1, "Allerheiligen")
+	christmas       = fixed(time.December, 25, "1. Weihnachtstag")
+	boxingDay       = fixed(time.December, 26, "2. Weihnachtstag")
+	germany         = []rule{newYear, goodFriday, easterMonday, labourDay, ascension, whitMonday, germanUnity, christmas, boxingDay, only(2017, reformation)}
+	reformationDays = []rule{since(2018, reformation)}
+)
+
+var regions = map[string][]rule{
+	"DE":    germany,
+	"DE-BW": append([]rule{epiphany, corpusChristi, allSaints}, germany...),
+	"DE-BY": append([]rule{epiphany, corpusChristi, assumption, allSaints}, germany...),
+	"DE-BE": append([]rule{since(2019, womensDay)}, germany...),
+	"DE-BB": append([]rule{easterDay, whitSunday, reformation}, germany[:len(germany)-1]...),
+	"DE-HB": append(reformationDays, germany...),
+	"DE-HH": append(reformationDays, germany...),
+	"DE-HE": append([]rule{corpusChristi}, germany...),
+	"DE-MV": append([]rule{since(2023, womensDay), reformation}, germany[:len(germany)-1]...),
+	"DE-NI": append(reformationDays, germany...),
+	"DE-NW": append([]rule{corpusChristi, allSaints}, germany...),
+	"DE-RP": append([]rule{corpusChristi, allSaints}, germany...),
+	"DE-SL": append([]rule{corpusChristi, assumption, allSaints}, germany...),
+	"DE-SN": append([]rule{reformation, repentance("Buß- und Bettag")}, germany[:len(germany)-1]...),
+	"DE-ST": append([]rule{epiphany, reformation}, germany[:len(germany)-1]...),
+	"DE-SH": append(reformationDays, germany...),
+	"DE-TH": append([]rule{since(2019, childrensDay), reformation}, germany[:len(germany)-1]...),
+	"AT": {
+		fixed(time.January, 1, "Neujahr"),
+		fixed(time.January, 6, "Heilige Drei Könige"),
+		easter(1, "Ostermontag"),
+		fixed(time.May, 1, "Staatsfeiertag"),
+		easter(39, "Christi Himmelfahrt"),
+		easter(50, "Pfingstmontag"),
+		easter(60, "Fronleichnam"),
+		fixed(time.August, 15, "Mariä Himmelfahrt"),
+		fixed(time.October, 26, "Nationalfeiertag"),
+		fixed(time.November, 1, "Allerheiligen"),
+		fixed(time.December, 8, "Mariä Empfängnis"),
+		fixed(time.December, 25, "Christtag"),
+		fixed(time.December, 26, "Stefanitag"),
+	},
+	"CH": {
+		fixed(time.January, 1, "Neujahr"),
+		easter(-2, "Karfreitag"),
+		easter(1, "Ostermontag"),
+		easter(39, "Auffahrt"),
+		easter(50, "Pfingstmontag"),
+		fixed(time.August, 1, "Bundesfeier"),
+		fixed(time.December, 25, "Weihnachten"),
+		fixed(time.December, 26, "Stephanstag"),
+	},
+	"NL": {
+		fixed(time.January, 1, "Nieuwjaarsdag"),
+		easter(0, "Eerste Paasdag"),
+		easter(1, "Tweede Paasdag"),
+		kingsDay("Koningsdag"),
+		lustrum(fixed(time.May, 5, "Bevrijdingsdag")),
+		easter(39, "Hemelvaartsdag"),
+		easter(49, "Eerste Pinksterdag"),
+		easter(50, "Tweede Pinksterdag"),
+		fixed(time.December, 25, "Eerste Kerstdag"),
+		fixed(time.December, 26, "Tweede Kerstdag"),
+	},
+	"GB": {
+		fixed(time.January, 1, "New Year's Day"),
+		easter(-2, "Good Friday"),
+		easter(1, "Easter Monday"),
+		weekday(time.May, time.Monday, 1, "Early May Bank Holiday"),
+		weekday(time.May, time.Monday, -1, "Spring Bank Holiday"),
+		weekday(time.August, time.Monday, -1, "Summer Bank Holiday"),
+		fixed(time.December, 25, "Christmas Day"),
+		fixed(time.December, 26, "Boxing Day"),
+	},
+	"US": {
+		fixed(time.January, 1, "New Year's Day"),
+		weekday(time.January, time.Monday, 3, "Birthday of Martin Luther King, Jr."),
+		weekday(time.February, time.Monday, 3, "Washington's Birthday"),
+		weekday(time.May, time.Monday, -1, "Memorial Day"),
+		since(2021, fixed(time.June, 19, "Juneteenth National Independence Day")),
+		fixed(time.July, 4, "Independence Day"),
+		weekday(time.September, time.Monday, 1, "Labor Day"),
+		weekday(time.October, time.Monday, 2, "Columbus Day"),
+		fixed(time.November, 11, "Veterans Day"),
+		weekday(time.November, time.Thursday, 4, "Thanksgiving Day"),
+		fixed(time.December, 25, "Christmas Day"),
+	},
+}
+
+// Regions returns the codes of every region with built-in holidays.
+func Regions() []string {
+	result := make([]string, 0, len(regions))
+	for region := range regions {
+		result = append(result, region)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func rules(region string) ([]rule, bool) {
+	holidays, ok := regions[strings.ToUpper(region)]
+	return holidays, ok
+}
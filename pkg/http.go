@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -29,6 +30,30 @@ func CreateJsonRequest(client *http.Client, httpMethod string, server *url.URL,
 	return response, err
 }
 
+// CreateTokenJsonRequest authenticates the request with the bearer token.
+func CreateTokenJsonRequest(client *http.Client, httpMethod string, server *url.URL, token string, payload io.Reader) (*http.Response, error) {
+	request, err := http.NewRequest(httpMethod, server.String(), payload)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	return response, err
+}
+
+// PrintRequest writes the method, the url and the body of a request, that is not sent.
+func PrintRequest(writer io.Writer, httpMethod string, server *url.URL, payload []byte) {
+	fmt.Fprintf(writer, "%s %s\n", httpMethod, server.String())
+	if len(payload) > 0 {
+		fmt.Fprintf(writer, "%s\n", payload)
+	}
+}
+
 type RoundTripFunc func(req *http.Request) *http.Response
 
 func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -0,0 +1,236 @@
+package pkg
+
+import (
+	"eager/internal"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Rates are the hourly rates of the configuration.
+type Rates []internal.Rate
+
+type Invoice struct {
+	Year     int           `json:"year"`
+	Month    time.Month    `json:"month"`
+	Currency string        `json:"currency,omitempty"`
+	Items    []InvoiceItem `json:"items"`
+	Hours    float64       `json:"hours"`
+	Amount   float64       `json:"amount"`
+}
+
+// InvoiceItem is the billable effort of a user for a task with the same rate.
+type InvoiceItem struct {
+	User     string  `json:"user,omitempty"`
+	Customer string  `json:"customer,omitempty"`
+	Project  Project `json:"project"`
+	Task     Task    `json:"task"`
+	Hours    float64 `json:"hours"`
+	Rate     float64 `json:"rate"`
+	Amount   float64 `json:"amount"`
+}
+
+// Rate returns the rate of the effort. The most specific rate wins, the task before the project before the customer before the user.
+// Between rates with the same keys, the first one wins.
+func (rates Rates) Rate(effort Effort) (float64, bool) {
+	best := -1
+	result := 0.0
+	for _, rate := range rates {
+		weight := 0
+		if rate.User != "" {
+			if effort.User == nil || (!strings.EqualFold(rate.User, effort.User.DisplayName) && rate.User != effort.User.Id) {
+				continue
+			}
+			weight += 1
+		}
+		if rate.Customer != "" {
+			if !strings.EqualFold(rate.Customer, effort.Attributes[AttributeCustomer]) {
+				continue
+			}
+			weight += 2
+		}
+		if rate.Project != "" {
+			if !strings.EqualFold(rate.Project, string(effort.Project)) {
+				continue
+			}
+			weight += 4
+		}
+		if rate.Task != "" {
+			if !strings.EqualFold(rate.Task, string(effort.Task)) {
+				continue
+			}
+			weight += 8
+		}
+		if weight > best {
+			best = weight
+			result = rate.Rate
+		}
+	}
+	return result, best >= 0
+}
+
+// Invoice returns the line items of the billable efforts inside the month. Every billable effort needs a rate.
+func (ts Timesheet) Invoice(year int, month time.Month, rates Rates, currency string) (*Invoice, error) {
+	fromDate, toDate := GetTimeRange(year, month)
+	type Key struct {
+		user     string
+		customer string
+		project  Project
+		task     Task
+		rate     float64
+	}
+	durations := map[Key]time.Duration{}
+	var missing []string
+	for _, effort := range ts {
+		if effort.Date.Before(fromDate) || !effort.Date.Before(toDate) {
+			continue
+		}
+		duration := effort.BillableDuration()
+		if duration <= 0 {
+			continue
+		}
+		rate, ok := rates.Rate(effort)
+		if !ok {
+			task := fmt.Sprintf("%s/%s", effort.Project, effort.Task)
+			if !contains(missing, task) {
+				missing = append(missing, task)
+			}
+			continue
+		}
+		key := Key{customer: effort.Attributes[AttributeCustomer], project: effort.Project, task: effort.Task, rate: rate}
+		if effort.User != nil {
+			key.user = effort.User.DisplayName
+		}
+		durations[key] += duration
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("found no rate for %s", strings.Join(missing, ", "))
+	}
+
+	invoice := &Invoice{
+		Year:     year,
+		Month:    month,
+		Currency: currency,
+		Items:    make([]InvoiceItem, 0, len(durations)),
+	}
+	for key, duration := range durations {
+		// The amount is computed from the displayed hours, so that hours times rate matches every line
+		hours := math.Round(duration.Hours()*100) / 100
+		item := InvoiceItem{
+			User:     key.user,
+			Customer: key.customer,
+			Project:  key.project,
+			Task:     key.task,
+			Hours:    hours,
+			Rate:     key.rate,
+			Amount:   math.Round(hours*key.rate*100) / 100,
+		}
+		invoice.Items = append(invoice.Items, item)
+		invoice.Hours += item.Hours
+		invoice.Amount += item.Amount
+	}
+	invoice.Hours = math.Round(invoice.Hours*100) / 100
+	invoice.Amount = math.Round(invoice.Amount*100) / 100
+	sort.Slice(invoice.Items, func(i, j int) bool {
+		a, b := invoice.Items[i], invoice.Items[j]
+		if a.Customer != b.Customer {
+			return a.Customer < b.Customer
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Task != b.Task {
+			return a.Task < b.Task
+		}
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		return a.Rate < b.Rate
+	})
+	return invoice, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (invoice *Invoice) records() [][]string {
+	amount := func(value float64) string {
+		if invoice.Currency == "" {
+			return fmt.Sprintf("%.02f", value)
+		}
+		return fmt.Sprintf("%.02f %s", value, invoice.Currency)
+	}
+	records := make([][]string, 0, len(invoice.Items)+2)
+	records = append(records, []string{"User", "Customer", "Project", "Task", "Hours", "Rate", "Amount"})
+	for _, item := range invoice.Items {
+		records = append(records, []string{item.User, item.Customer, string(item.Project), string(item.Task), fmt.Sprintf("%.02f", item.Hours), amount(item.Rate), amount(item.Amount)})
+	}
+	return append(records, []string{"Total", "", "", "", fmt.Sprintf("%.02f", invoice.Hours), "", amount(invoice.Amount)})
+}
+
+func (invoice *Invoice) WriteCsv(writer io.Writer) error {
+	csvw := csv.NewWriter(writer)
+	csvw.Comma = ';'
+	return csvw.WriteAll(invoice.records())
+}
+
+func (invoice *Invoice) WriteJson(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(invoice)
+}
+
+var invoiceHtml = template.Must(template.New("invoice").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Year}}-{{printf "%02d" .Month}}</title>
+<style>
+table { border-collapse: collapse; font-family: sans-serif; font-size: small; }
+th, td { border: 1px solid #999; padding: 2px 4px; }
+td:nth-child(n+5), th:nth-child(n+5) { text-align: right; }
+thead th, tfoot th { background: #eee; }
+</style>
+</head>
+<body>
+<table>
+<thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Body}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+<tfoot><tr>{{range .Footer}}<th>{{.}}</th>{{end}}</tr></tfoot>
+</table>
+</body>
+</html>
+`))
+
+func (invoice *Invoice) WriteHtml(writer io.Writer) error {
+	records := invoice.records()
+	return invoiceHtml.Execute(writer, struct {
+		Year   int
+		Month  int
+		Header []string
+		Body   [][]string
+		Footer []string
+	}{
+		Year:   invoice.Year,
+		Month:  int(invoice.Month),
+		Header: records[0],
+		Body:   records[1 : len(records)-1],
+		Footer: records[len(records)-1],
+	})
+}
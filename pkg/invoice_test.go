@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRate(t *testing.T) {
+	rates := Rates{
+		{Rate: 80},
+		{User: "Max", Rate: 90},
+		{Customer: "ACME", Rate: 100},
+		{Project: "PROJ", Rate: 110},
+		{Project: "PROJ", Task: "PROJ-1", Rate: 120},
+	}
+	max := &User{DisplayName: "Max"}
+	tests := []struct {
+		name   string
+		effort Effort
+		want   float64
+	}{
+		{name: "default", effort: Effort{Project: "OTHER"}, want: 80},
+		{name: "user", effort: Effort{User: max, Project: "OTHER"}, want: 90},
+		{name: "customer", effort: Effort{User: max, Project: "OTHER", Attributes: map[string]string{AttributeCustomer: "acme"}}, want: 100},
+		{name: "project", effort: Effort{User: max, Project: "PROJ", Attributes: map[string]string{AttributeCustomer: "ACME"}}, want: 110},
+		{name: "task", effort: Effort{User: max, Project: "PROJ", Task: "PROJ-1"}, want: 120},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, ok := rates.Rate(test.effort); !ok || got != test.want {
+				t.Errorf("got %v want %v", got, test.want)
+			}
+		})
+	}
+	if _, ok := (Rates{{Project: "PROJ", Rate: 1}}).Rate(Effort{Project: "OTHER"}); ok {
+		t.Errorf("got rate for other project")
+	}
+}
+
+func TestInvoice(t *testing.T) {
+	date := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	max := &User{DisplayName: "Max"}
+	timesheet := Timesheet{
+		{User: max, Project: "PROJ", Task: "PROJ-1", Date: date, Duration: time.Hour},
+		{User: max, Project: "PROJ", Task: "PROJ-1", Date: date.AddDate(0, 0, 1), Duration: 30 * time.Minute, Billing: BillingBillable},
+		{User: max, Project: "PROJ", Task: "PROJ-2", Date: date, Duration: time.Hour, Billing: BillingNonBillable},
+		{User: max, Project: "PROJ", Task: "PROJ-3", Date: date, Duration: 2 * time.Hour, Attributes: map[string]string{AttributeBillable: "1h0m0s"}},
+		{User: max, Project: "PROJ", Task: "PROJ-1", Date: date.AddDate(0, 1, 0), Duration: time.Hour},
+	}
+	invoice, err := timesheet.Invoice(2022, time.August, Rates{{Project: "PROJ", Rate: 100}}, "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Invoice{
+		Year:     2022,
+		Month:    time.August,
+		Currency: "EUR",
+		Items: []InvoiceItem{
+			{User: "Max", Project: "PROJ", Task: "PROJ-1", Hours: 1.5, Rate: 100, Amount: 150},
+			{User: "Max", Project: "PROJ", Task: "PROJ-3", Hours: 1, Rate: 100, Amount: 100},
+		},
+		Hours:  2.5,
+		Amount: 250,
+	}
+	if !reflect.DeepEqual(invoice, want) {
+		t.Errorf("got %v want %v", invoice, want)
+	}
+
+	// The amount is computed from the displayed hours
+	third := Timesheet{{User: max, Project: "PROJ", Task: "PROJ-1", Date: date, Duration: 20 * time.Minute}}
+	invoice, err = third.Invoice(2022, time.August, Rates{{Project: "PROJ", Rate: 100}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invoice.Items[0].Hours != 0.33 || invoice.Items[0].Amount != 33 || invoice.Amount != 33 {
+		t.Errorf("got %v want 0.33 hours and amount 33", invoice.Items[0])
+	}
+
+	_, err = timesheet.Invoice(2022, time.August, Rates{{Task: "PROJ-1", Rate: 100}}, "")
+	if err == nil || err.Error() != "found no rate for PROJ/PROJ-3" {
+		t.Errorf("got %v want missing rate", err)
+	}
+}
+
+func TestBillingLabels(t *testing.T) {
+	labels := NewBillingLabels([]string{"billable"}, []string{"internal"})
+	tests := []struct {
+		labels []string
+		want   Billing
+	}{
+		{labels: []string{"Billable"}, want: BillingBillable},
+		{labels: []string{"billable", "internal"}, want: BillingNonBillable},
+		{labels: []string{"other"}, want: BillingNonBillable},
+		{want: BillingNonBillable},
+	}
+	for _, test := range tests {
+		if got := labels.Apply(Effort{}, MappingFields{MapLabel: test.labels}).Billing; got != test.want {
+			t.Errorf("got %s want %s for %v", got, test.want, test.labels)
+		}
+	}
+	// Without billable labels, every effort without non-billable label is billable
+	labels = NewBillingLabels(nil, []string{"internal"})
+	if got := labels.Apply(Effort{}, MappingFields{MapLabel: {"other"}}).Billing; got != BillingBillable {
+		t.Errorf("got %s want %s", got, BillingBillable)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"eager/pkg"
 	"eager/pkg/jira/model"
 	"eager/pkg/jira/v2"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
@@ -17,7 +18,9 @@ type Api struct {
 	Client   *http.Client
 	Server   *url.URL
 	Userinfo *url.Userinfo
-	v2       *v2.Api
+	// DryRun prints every change instead of sending it, if it is set
+	DryRun io.Writer
+	v2     *v2.Api
 }
 
 func (api Api) previousVersion() *v2.Api {
@@ -26,6 +29,8 @@ func (api Api) previousVersion() *v2.Api {
 			Client:   api.Client,
 			Server:   api.Server,
 			Userinfo: api.Userinfo,
+			Document: true,
+			DryRun:   api.DryRun,
 		}
 	}
 	return api.v2
@@ -39,16 +44,16 @@ func (api Api) User(user *pkg.User) (model.Account, *time.Location, error) {
 	return api.previousVersion().User(user)
 }
 
-func (api Api) Issues(jql model.Jql, issueFunc model.IssueFunc) error {
-	return api.previousVersion().Issues(jql, issueFunc)
+func (api Api) Issues(jql model.Jql, fields []string, issueFunc model.IssueFunc) error {
+	return api.previousVersion().Issues(jql, fields, issueFunc)
 }
 
 func (api Api) Worklog(key model.IssueKey, worklogFunc model.WorklogFunc) error {
 	return api.previousVersion().Worklog(key, worklogFunc)
 }
 
-func (api Api) AddWorklog(key model.IssueKey, date time.Time, duration time.Duration) error {
-	return api.previousVersion().AddWorklog(key, date, duration)
+func (api Api) AddWorklog(key model.IssueKey, date time.Time, duration time.Duration, description pkg.Description) (model.WorklogId, error) {
+	return api.previousVersion().AddWorklog(key, date, duration, description)
 }
 
 func (api Api) RemoveWorklog(key model.IssueKey, id model.WorklogId) error {
@@ -1,10 +1,12 @@
 package jira
 
 import (
+	"eager/internal"
 	"eager/pkg"
 	"eager/pkg/jira/cloud"
 	"eager/pkg/jira/model"
 	"eager/pkg/jira/v2"
+	"eager/pkg/journal"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -20,7 +22,9 @@ const (
 	jiraServerInfo = "/rest/api/latest/serverInfo"
 )
 
-func getApiVersion(client *http.Client, server *url.URL, userinfo *url.Userinfo) (model.Api, error) {
+// getApiVersion returns the api of the deployment, that makes every change of a worklog as given by the changes.
+// Without changes, worklogs are changed without journal.
+func getApiVersion(client *http.Client, server *url.URL, userinfo *url.Userinfo, changes *journal.Changes) (model.Api, error) {
 	infoUrl, err := server.Parse(fmt.Sprintf(jiraServerInfo))
 	response, err := pkg.CreateJsonRequest(client, http.MethodGet, infoUrl, userinfo, nil)
 	if err != nil {
@@ -49,120 +53,226 @@ func getApiVersion(client *http.Client, server *url.URL, userinfo *url.Userinfo)
 	// There are "Cloud" and "Server" deployment types.
 	if strings.ToLower(result.DeploymentType) == "cloud" {
 		path, _ := server.Parse(cloud.BasePath)
-		return &cloud.Api{
+		return journaled(&cloud.Api{
 			Client:   client,
 			Server:   path,
 			Userinfo: userinfo,
-		}, nil
+			DryRun:   changes.Writer(),
+		}, server, changes), nil
 	}
 	path, _ := server.Parse(v2.BasePath)
-	return &v2.Api{
+	return journaled(&v2.Api{
 		Client:   client,
 		Server:   path,
 		Userinfo: userinfo,
-	}, nil
+		DryRun:   changes.Writer(),
+	}, server, changes), nil
 }
 
-func GetTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, projects []pkg.Project) pkg.Timesheet {
-	api, err := getApiVersion(client, server, userinfo)
+// NewApi returns the api of the Jira deployment, that changes worklogs as given by the changes.
+func NewApi(client *http.Client, server *url.URL, userinfo *url.Userinfo, changes *journal.Changes) (model.Api, error) {
+	return getApiVersion(client, server, userinfo, changes)
+}
+
+// IsCloud tells, if the api belongs to a Jira Cloud deployment.
+func IsCloud(api model.Api) bool {
+	if recorder, ok := api.(*journalApi); ok {
+		api = recorder.Api
+	}
+	_, ok := api.(*cloud.Api)
+	return ok
+}
+
+// Accounts returns the account of every user.
+func Accounts(api model.Api, users []*pkg.User) (map[model.Account]*pkg.User, error) {
+	return accounts(api, users)
+}
+
+// GetTimesheet returns the effort of the current user between both days, excluding the last day, for the issues of the query with the given issue attributes.
+// The optional mapping is applied to project and task of every effort, the optional billing labels to the billing status.
+// The days of the efforts are in the time zone of the user or in the optional location.
+func GetTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, fromDate, toDate time.Time, projects []pkg.Project, query model.Jql, attributes []pkg.Attribute, mapping *pkg.Mapping, billing *pkg.BillingLabels, location *time.Location) (pkg.Timesheet, error) {
+	api, err := getApiVersion(client, server, userinfo, nil)
 	if err != nil {
-		log.Println("Could not get api version.", err)
-		return pkg.Timesheet{}
+		return nil, fmt.Errorf("could not get api version. %s", err.Error())
 	}
 
 	accountId, timezone, err := api.Me()
 	if err != nil {
-		log.Println("Could not get user.", err)
-		return pkg.Timesheet{}
+		return nil, fmt.Errorf("could not get user. %s", err.Error())
 	}
 	accounts := map[model.Account]*pkg.User{}
 	accounts[accountId] = &pkg.User{
 		TimeZone: timezone,
 	}
 
-	return do(api, year, month, projects, accounts)
+	return do(api, fromDate, toDate, projects, query, timezone, inLocation(accounts, location), attributes, mapping, billing)
 }
 
-func GetBulkTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, projects []pkg.Project, users []*pkg.User) pkg.Timesheet {
-	var err error
-	api, err := getApiVersion(client, server, userinfo)
+// GetBulkTimesheet returns the effort of the given users between both days, excluding the last day, for the issues of the query with the given issue attributes.
+// The optional mapping is applied to project and task of every effort, the optional billing labels to the billing status.
+// The days of the efforts are in the time zone of every user or in the optional location.
+func GetBulkTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, fromDate, toDate time.Time, projects []pkg.Project, users []*pkg.User, query model.Jql, attributes []pkg.Attribute, mapping *pkg.Mapping, billing *pkg.BillingLabels, location *time.Location) (pkg.Timesheet, error) {
+	api, err := getApiVersion(client, server, userinfo, nil)
 	if err != nil {
-		log.Println("Could not get api version.", err)
-		return pkg.Timesheet{}
+		return nil, fmt.Errorf("could not get api version. %s", err.Error())
+	}
+
+	_, timezone, err := api.Me()
+	if err != nil {
+		return nil, fmt.Errorf("could not get user. %s", err.Error())
 	}
 
 	accounts, err := accounts(api, users)
 	if err != nil {
-		log.Println("Could not get user.", err)
-		return pkg.Timesheet{}
+		return nil, fmt.Errorf("could not get user. %s", err.Error())
 	}
 
-	return do(api, year, month, projects, accounts)
+	return do(api, fromDate, toDate, projects, query, timezone, inLocation(accounts, location), attributes, mapping, billing)
 }
 
-func AddWorklogItem(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, day int, task pkg.Task, duration time.Duration, sum bool, confirm model.WorklogFunc) {
-	var err error
-	api, err := getApiVersion(client, server, userinfo)
+// AddWorklogItem adds the duration to the worklog of the task.
+// Rounding per entry rounds the given duration, rounding per day rounds the sum of the effort for that day and task.
+// A summary replaces the existing worklogs of that day, if they are confirmed.
+func AddWorklogItem(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, day int, task pkg.Task, duration time.Duration, sum bool, rounding internal.Rounding, confirm pkg.ConfirmFunc, changes *journal.Changes) error {
+	api, err := getApiVersion(client, server, userinfo, changes)
 	if err != nil {
-		log.Println("Could not get api version.", err)
-		return
+		return fmt.Errorf("could not get api version. %s", err.Error())
 	}
 
 	account, location, err := api.Me()
 	if err != nil {
-		log.Println("Could not get user.", err)
-		return
+		return fmt.Errorf("could not get user. %s", err.Error())
 	}
 
 	key := model.IssueKey(task)
+	if rounding.Scope == pkg.RoundEntry {
+		duration, err = pkg.RoundBooking(duration, rounding)
+		if err != nil {
+			return err
+		}
+	}
 
 	if !sum {
 		// Add new effort
-		err = api.AddWorklog(key, adjustDateTime(location, duration, year, month, day), duration)
+		_, err = api.AddWorklog(key, AdjustDateTime(location, duration, year, month, day), duration, "")
 		if err != nil {
-			log.Println("Could not add effort.", err)
+			return fmt.Errorf("could not add effort. %s", err.Error())
 		}
-		return
+		return nil
 	}
 
 	// Check, if there is already effort inside the worklog
-	var effort []model.Worklog
-	err = api.Worklog(key, func(worklog model.Worklog) bool {
-		wd := worklog.Date().In(location)
-		if worklog.Author().Id() == account && year == wd.Year() && month == wd.Month() && day == wd.Day() {
-			effort = append(effort, worklog)
-		}
-		return true
-	})
+	effort, err := worklogsOfDay(api, key, account, year, month, day, location)
 	if err != nil {
-		log.Println("Could not get worklog.", err)
-		return
+		return err
 	}
 
 	// Collect effort for that day
 	for _, worklog := range effort {
 		duration += worklog.Duration()
 	}
+	if rounding.Scope == pkg.RoundDay {
+		duration, err = pkg.RoundBooking(duration, rounding)
+		if err != nil {
+			return err
+		}
+	}
+
+	items := make([]fmt.Stringer, len(effort))
+	for i, worklog := range effort {
+		items[i] = worklog
+	}
+	ok, err := confirm(fmt.Sprintf("Replace these worklogs of %s with %s", key, duration), items)
+	if err != nil || !ok {
+		return err
+	}
 
 	// Add new effort
-	err = api.AddWorklog(key, adjustDateTime(location, duration, year, month, day), duration)
+	_, err = api.AddWorklog(key, AdjustDateTime(location, duration, year, month, day), duration, "")
 	if err != nil {
-		log.Println("Could not add effort.", err)
-		return
+		return fmt.Errorf("could not add effort. %s", err.Error())
 	}
 
 	// Delete old effort
 	for _, worklog := range effort {
-		if confirm(worklog) {
-			err = api.RemoveWorklog(key, worklog.Id())
+		err = api.RemoveWorklog(key, worklog.Id())
+		if err != nil {
+			return fmt.Errorf("could not remove effort. %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// AddTimesheet adds every effort of the timesheet to the worklog of its task for the current user.
+// Efforts, which are already inside the worklog at the same day with the same duration, are skipped.
+// With a dry run, every new worklog is printed instead of added and its booking is planned.
+// The result contains the booking of every effort in the order of the timesheet.
+func AddTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, timesheet pkg.Timesheet, changes *journal.Changes) ([]pkg.Booking, error) {
+	var err error
+	api, err := getApiVersion(client, server, userinfo, changes)
+	if err != nil {
+		return nil, fmt.Errorf("could not get api version. %s", err.Error())
+	}
+
+	account, location, err := api.Me()
+	if err != nil {
+		return nil, fmt.Errorf("could not get user. %s", err.Error())
+	}
+
+	// Day and duration of every worklog per issue
+	type Key struct {
+		date     string
+		duration time.Duration
+	}
+	existing := map[model.IssueKey]map[Key]bool{}
+	result := make([]pkg.Booking, len(timesheet))
+	for i, effort := range timesheet {
+		result[i].Row = i + 1
+		result[i].Effort = effort
+		key := model.IssueKey(effort.Task)
+		if _, ok := existing[key]; !ok {
+			worklogs := map[Key]bool{}
+			err = api.Worklog(key, func(worklog model.Worklog) bool {
+				if worklog.Author().Id() == account {
+					worklogs[Key{worklog.Date().In(location).Format(pkg.IsoYearMonthDay), worklog.Duration()}] = true
+				}
+				return true
+			})
 			if err != nil {
-				log.Println("Could not remove effort.", err)
+				result[i].Status = pkg.BookingFailed
+				result[i].Message = fmt.Sprintf("could not get worklog. %s", err.Error())
+				continue
 			}
+			existing[key] = worklogs
+		}
+
+		booked := Key{effort.Date.Format(pkg.IsoYearMonthDay), effort.Duration}
+		if existing[key][booked] {
+			result[i].Status = pkg.BookingDuplicate
+			continue
+		}
+		existing[key][booked] = true
+
+		year, month, day := effort.Date.Date()
+		_, err = api.AddWorklog(key, AdjustDateTime(location, effort.Duration, year, month, day), effort.Duration, effort.Description)
+		if err != nil {
+			result[i].Status = pkg.BookingFailed
+			result[i].Message = fmt.Sprintf("could not add effort. %s", err.Error())
+			continue
 		}
+		if changes.Writer() != nil {
+			// The dry run printed the worklog instead of adding it
+			result[i].Status = pkg.BookingPlanned
+			continue
+		}
+		result[i].Status = pkg.BookingAdded
 	}
+	return result, nil
 }
 
-func adjustDateTime(location *time.Location, duration time.Duration, year int, month time.Month, day int) time.Time {
+// AdjustDateTime returns the start of the effort, that ends now on the current day or starts at midnight on another day.
+func AdjustDateTime(location *time.Location, duration time.Duration, year int, month time.Month, day int) time.Time {
 	// Get the current date and time
 	// Sub the given duration
 	date := time.Now().In(location).Add(-duration)
@@ -174,68 +284,196 @@ func adjustDateTime(location *time.Location, duration time.Duration, year int, m
 	return date
 }
 
-func RemoveWorklogItem(client *http.Client, server *url.URL, userinfo *url.Userinfo, year int, month time.Month, day int, task pkg.Task, confirm model.WorklogFunc) {
-	var err error
-	api, err := getApiVersion(client, server, userinfo)
+// RemoveWorklogItem removes the selected worklogs of the current user, if they are confirmed.
+// Without query, the worklogs of the selected task are read directly. Otherwise, the issues of the query
+// and the selected task are searched for worklogs between the first and the last day of the selection.
+func RemoveWorklogItem(client *http.Client, server *url.URL, userinfo *url.Userinfo, query model.Jql, selection pkg.Selection, confirm pkg.ConfirmFunc, changes *journal.Changes) error {
+	api, err := getApiVersion(client, server, userinfo, changes)
 	if err != nil {
-		log.Println("Could not get api version.", err)
-		return
+		return fmt.Errorf("could not get api version. %s", err.Error())
 	}
 
 	account, location, err := api.Me()
 	if err != nil {
-		log.Println("Could not get user.", err)
-		return
+		return fmt.Errorf("could not get user. %s", err.Error())
 	}
+	return removeWorklogs(api, account, location, query, selection, confirm)
+}
 
-	key := model.IssueKey(task)
-	date := time.Date(year, month, day, 0, 0, 0, 0, location)
+// removeWorklogs removes the selected worklogs of the account, if they are confirmed. The days are in the location.
+func removeWorklogs(api model.Api, account model.Account, location *time.Location, query model.Jql, selection pkg.Selection, confirm pkg.ConfirmFunc) error {
+	var timesheet pkg.Timesheet
+	var err error
+	switch {
+	case selection.Task != "" && len(query) == 0:
+		timesheet, err = taskTimesheet(api, model.IssueKey(selection.Task), account, location)
+		if err != nil {
+			return err
+		}
+	case selection.Bounded():
+		if selection.Task != "" {
+			query = new(model.Jql).Keys(model.IssueKey(selection.Task)).And(query)
+		}
+		accounts := map[model.Account]*pkg.User{account: {TimeZone: location}}
+		timesheet, err = do(api, selection.From, selection.To.AddDate(0, 0, 1), nil, query, location, accounts, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("the selection needs a task or a first and last day")
+	}
+	timesheet = timesheet.Select(selection)
+	if len(timesheet) == 0 {
+		return fmt.Errorf("found no worklog")
+	}
 
-	// Check, if there is already effort inside the worklog
-	err = api.Worklog(key, func(worklog model.Worklog) bool {
-		wd := worklog.Date()
-		if worklog.Author().Id() == account && date.Year() == wd.Year() && date.Month() == wd.Month() && date.Day() == wd.Day() {
-			if confirm(worklog) {
-				err = api.RemoveWorklog(key, worklog.Id())
-				if err != nil {
-					log.Println("Could not remove effort.", err)
-					return false
-				}
-			}
+	ok, err := confirm("Remove these worklogs", timesheet.Stringers())
+	if err != nil || !ok {
+		return err
+	}
+	for _, effort := range timesheet {
+		err = api.RemoveWorklog(model.IssueKey(effort.Task), model.WorklogId(effort.Id))
+		if err != nil {
+			return fmt.Errorf("could not remove effort. %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// taskTimesheet returns every effort of the account inside the worklog of the issue. The days are in the location.
+func taskTimesheet(api model.Api, key model.IssueKey, account model.Account, location *time.Location) (pkg.Timesheet, error) {
+	var timesheet pkg.Timesheet
+	err := api.Worklog(key, func(worklog model.Worklog) bool {
+		if worklog.Author().Id() != account {
+			return true
 		}
+		started := worklog.Date().In(location)
+		timesheet = append(timesheet, pkg.Effort{
+			Id:          string(worklog.Id()),
+			Task:        pkg.Task(key),
+			Description: worklog.Comment(),
+			Date:        time.Date(started.Year(), started.Month(), started.Day(), 0, 0, 0, 0, time.UTC),
+			Start:       started,
+			Duration:    worklog.Duration(),
+		})
 		return true
 	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get worklog. %s", err.Error())
+	}
+	return timesheet, nil
 }
 
-func do(api model.Api, year int, month time.Month, projects []pkg.Project, accounts map[model.Account]*pkg.User) pkg.Timesheet {
-	var err error
+// worklogsOfDay returns the worklogs of the account for the issue on that day inside the location.
+func worklogsOfDay(api model.Api, key model.IssueKey, account model.Account, year int, month time.Month, day int, location *time.Location) ([]model.Worklog, error) {
+	var effort []model.Worklog
+	err := api.Worklog(key, func(worklog model.Worklog) bool {
+		wd := worklog.Date().In(location)
+		if worklog.Author().Id() == account && year == wd.Year() && month == wd.Month() && day == wd.Day() {
+			effort = append(effort, worklog)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get worklog. %s", err.Error())
+	}
+	return effort, nil
+}
 
-	// TODO Calculate max timezone offset for each user to have the right from and to date.
-	// The jql query uses afaik the time zone of the requesting user.
-	fromDate, toDate := pkg.GetTimeRange(year, month)
+// inLocation sets the time zone of every user to the location. Without location, the users are unchanged.
+func inLocation(accounts map[model.Account]*pkg.User, location *time.Location) map[model.Account]*pkg.User {
+	if location == nil {
+		return accounts
+	}
+	// The users are shared with the caller, so the time zone is set on copies
+	result := make(map[model.Account]*pkg.User, len(accounts))
+	for account, user := range accounts {
+		copied := *user
+		copied.TimeZone = location
+		result[account] = &copied
+	}
+	return result
+}
 
+// window returns the range of days in the time zone of the requesting user, that contains the range of days in every location.
+// Dates are represented in UTC like the result of pkg.GetTimeRange.
+func window(fromDate, toDate time.Time, requester *time.Location, locations []*time.Location) (time.Time, time.Time) {
+	day := func(date time.Time) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	from, to := fromDate, toDate
+	for _, location := range locations {
+		start := time.Date(fromDate.Year(), fromDate.Month(), fromDate.Day(), 0, 0, 0, 0, location).In(requester)
+		if d := day(start); d.Before(from) {
+			from = d
+		}
+		end := time.Date(toDate.Year(), toDate.Month(), toDate.Day(), 0, 0, 0, 0, location).In(requester)
+		d := day(end)
+		if end.Hour() != 0 || end.Minute() != 0 || end.Second() != 0 {
+			// The end is exclusive, so the started day must be included
+			d = d.AddDate(0, 0, 1)
+		}
+		if d.After(to) {
+			to = d
+		}
+	}
+	return from, to
+}
+
+// do returns the effort of the accounts between both days, excluding the last day.
+func do(api model.Api, fromDate, toDate time.Time, projects []pkg.Project, query model.Jql, requester *time.Location, accounts map[model.Account]*pkg.User, attributes []pkg.Attribute, mapping *pkg.Mapping, billing *pkg.BillingLabels) (pkg.Timesheet, error) {
 	i := 0
 	accountIds := make([]model.Account, len(accounts))
-	for account := range accounts {
+	locations := make([]*time.Location, 0, len(accounts))
+	// The users are shared with the caller, users without time zone are copied
+	users := make(map[model.Account]*pkg.User, len(accounts))
+	for account, user := range accounts {
 		accountIds[i] = account
 		i++
+		if user.TimeZone == nil {
+			copied := *user
+			copied.TimeZone = time.UTC
+			user = &copied
+		}
+		users[account] = user
+		locations = append(locations, user.TimeZone)
+	}
+	if requester == nil {
+		requester = time.UTC
 	}
 
-	jql := new(model.Jql).Between(fromDate, toDate).Users(accountIds...).Projects(projects...)
+	// The jql query uses the time zone of the requesting user.
+	// Widen the range by the offset of every user and filter the exact days afterwards.
+	jqlFrom, jqlTo := window(fromDate, toDate, requester, locations)
+	jql := new(model.Jql).Between(jqlFrom, jqlTo).Users(accountIds...).Projects(projects...).And(query)
+	fields := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		fields[i] = attribute.Field
+	}
+	if epicLink := mapping.EpicLink(); epicLink != "" {
+		fields = append(fields, epicLink)
+	}
 
-	// The chan for errors
-	errors := make(chan error)
+	// The first error of any request
+	var mutex sync.Mutex
+	var failure error
+	fail := func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if failure == nil {
+			failure = err
+		}
+	}
 
 	// The chan for issues
 	issues := make(chan model.Issue)
 	go func() {
 		defer close(issues)
-		err = api.Issues(jql, func(issue model.Issue) {
+		err := api.Issues(jql, fields, func(issue model.Issue) {
 			issues <- issue
 		})
 		if err != nil {
-			log.Println("Could not get issues.", err)
-			errors <- err
+			fail(fmt.Errorf("could not get issues. %s", err.Error()))
 		}
 	}()
 
@@ -254,29 +492,36 @@ func do(api model.Api, year int, month time.Month, projects []pkg.Project, accou
 					<-throttle
 					wg.Done()
 				}()
-				err = api.Worklog(issue.Key(), func(worklog model.Worklog) bool {
+				values := map[string]string{}
+				for _, attribute := range attributes {
+					values[attribute.Name] = issue.Attribute(attribute.Field)
+				}
+				fields := mappingFields(issue, values, mapping.EpicLink())
+				err := api.Worklog(issue.Key(), func(worklog model.Worklog) bool {
 					account := worklog.Author().Id()
-					user := accounts[account]
+					user := users[account]
 					if user == nil {
 						return true
 					}
-					date := worklog.Date().In(user.TimeZone)
-					date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+					started := worklog.Date().In(user.TimeZone)
+					date := time.Date(started.Year(), started.Month(), started.Day(), 0, 0, 0, 0, time.UTC)
 					if !date.Before(fromDate) && date.Before(toDate) {
-						effort <- pkg.Effort{
+						effort <- billing.Apply(mapping.Apply(pkg.Effort{
 							User:        user,
 							Description: worklog.Comment(),
 							Project:     issue.Project(),
 							Task:        pkg.Task(issue.Key()),
 							Date:        date,
+							Start:       started,
 							Duration:    worklog.Duration(),
-						}
+							Attributes:  values,
+							Id:          string(worklog.Id()),
+						}, fields), fields)
 					}
 					return true
 				})
 				if err != nil {
-					log.Println("Could not get effort for "+issue.Key(), err)
-					errors <- err
+					fail(fmt.Errorf("could not get effort for %s. %s", issue.Key(), err.Error()))
 				}
 			}(issue)
 		}
@@ -284,17 +529,68 @@ func do(api model.Api, year int, month time.Month, projects []pkg.Project, accou
 	}()
 
 	var timesheet pkg.Timesheet
-	go func() {
-		defer close(errors)
-		for e := range effort {
-			timesheet = append(timesheet, e)
+	for e := range effort {
+		timesheet = append(timesheet, e)
+	}
+	if failure != nil {
+		return nil, failure
+	}
+	return timesheet, nil
+}
+
+// MapIssue returns the fields of the issue and the target of the mapping or nil, if nothing matches.
+func MapIssue(client *http.Client, server *url.URL, userinfo *url.Userinfo, key pkg.Task, attributes []pkg.Attribute, mapping *pkg.Mapping) (pkg.MappingFields, *pkg.MappingMatch, error) {
+	api, err := getApiVersion(client, server, userinfo, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get api version. %s", err.Error())
+	}
+	requested := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		requested[i] = attribute.Field
+	}
+	if epicLink := mapping.EpicLink(); epicLink != "" {
+		requested = append(requested, epicLink)
+	}
+	var fields pkg.MappingFields
+	err = api.Issues(new(model.Jql).Keys(model.IssueKey(key)), requested, func(issue model.Issue) {
+		values := map[string]string{}
+		for _, attribute := range attributes {
+			values[attribute.Name] = issue.Attribute(attribute.Field)
 		}
-	}()
-	if <-errors != nil {
-		return pkg.Timesheet{}
+		fields = mappingFields(issue, values, mapping.EpicLink())
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get issue. %s", err.Error())
+	}
+	if fields == nil {
+		return nil, nil, fmt.Errorf("found no issue %s", key)
 	}
+	return fields, mapping.Match(fields), nil
+}
 
-	return timesheet
+// mappingFields returns the fields of the issue including the values of the attributes by attribute name.
+// The epic is read from the epic link field, if the issue has no parent epic, e.g. on Jira Server.
+func mappingFields(issue model.Issue, attributes map[string]string, epicLink string) pkg.MappingFields {
+	fields := pkg.MappingFields{}
+	for name, value := range attributes {
+		fields[name] = []string{value}
+	}
+	for name, values := range map[string][]string{
+		pkg.MapProject:   {string(issue.Project())},
+		pkg.MapIssue:     {string(issue.Key())},
+		pkg.MapComponent: issue.Components(),
+		pkg.MapLabel:     issue.Labels(),
+	} {
+		fields[name] = values
+	}
+	epic := string(issue.Epic())
+	if epic == "" && epicLink != "" {
+		epic = issue.Attribute(epicLink)
+	}
+	if epic != "" {
+		fields[pkg.MapEpic] = []string{epic}
+	}
+	return fields
 }
 
 func accounts(api model.Api, users []*pkg.User) (map[model.Account]*pkg.User, error) {
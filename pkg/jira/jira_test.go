@@ -1 +1,424 @@
 package jira
+
+import (
+	"eager/internal"
+	"eager/internal/fake"
+	"eager/pkg"
+	"eager/pkg/jira/model"
+	"eager/pkg/journal"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	honolulu   = time.FixedZone("UTC-10", -10*60*60)
+	kiritimati = time.FixedZone("UTC+14", 14*60*60)
+)
+
+func TestWindow(t *testing.T) {
+	fromDate, toDate := pkg.GetTimeRange(2022, time.August)
+	tests := []struct {
+		name      string
+		requester *time.Location
+		locations []*time.Location
+		from, to  string
+	}{
+		{name: "same", requester: time.UTC, locations: []*time.Location{time.UTC}, from: "2022-08-01", to: "2022-09-01"},
+		{name: "behind", requester: time.UTC, locations: []*time.Location{honolulu}, from: "2022-08-01", to: "2022-09-02"},
+		{name: "ahead", requester: time.UTC, locations: []*time.Location{kiritimati}, from: "2022-07-31", to: "2022-09-01"},
+		{name: "both", requester: time.UTC, locations: []*time.Location{honolulu, kiritimati}, from: "2022-07-31", to: "2022-09-02"},
+		{name: "requester ahead", requester: kiritimati, locations: []*time.Location{honolulu}, from: "2022-08-01", to: "2022-09-02"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from, to := window(fromDate, toDate, test.requester, test.locations)
+			if from.Format(pkg.IsoYearMonthDay) != test.from || to.Format(pkg.IsoYearMonthDay) != test.to {
+				t.Errorf("got %s - %s want %s - %s", from.Format(pkg.IsoYearMonthDay), to.Format(pkg.IsoYearMonthDay), test.from, test.to)
+			}
+		})
+	}
+}
+
+func TestTimeZones(t *testing.T) {
+	api := &fakeApi{worklogs: []fakeWorklog{
+		{account: "west", started: time.Date(2022, time.August, 31, 20, 0, 0, 0, honolulu)},
+		{account: "west", started: time.Date(2022, time.September, 1, 1, 0, 0, 0, honolulu)},
+		{account: "west", started: time.Date(2022, time.July, 31, 20, 0, 0, 0, honolulu)},
+		{account: "east", started: time.Date(2022, time.August, 1, 5, 0, 0, 0, kiritimati)},
+		{account: "east", started: time.Date(2022, time.July, 31, 23, 0, 0, 0, kiritimati)},
+	}}
+	accounts := map[model.Account]*pkg.User{
+		"west": {DisplayName: "West", TimeZone: honolulu},
+		"east": {DisplayName: "East", TimeZone: kiritimati},
+	}
+	august, september := pkg.GetTimeRange(2022, time.August)
+	timesheet, err := do(api, august, september, nil, nil, time.UTC, accounts, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(api.jql, "worklogDate >= '2022/07/31' AND worklogDate < '2022/09/02'") {
+		t.Errorf("query %s", api.jql)
+	}
+	var got []string
+	for _, effort := range timesheet {
+		got = append(got, effort.User.DisplayName+" "+effort.Date.Format(pkg.IsoYearMonthDay))
+	}
+	sort.Strings(got)
+	want := []string{"East 2022-08-01", "West 2022-08-31"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v want %v", got, want)
+	}
+
+	// Display every effort in UTC
+	timesheet, err = do(api, august, september, nil, nil, time.UTC, inLocation(accounts, time.UTC), nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, effort := range timesheet {
+		got = append(got, effort.User.DisplayName+" "+effort.Date.Format(pkg.IsoYearMonthDay))
+	}
+	sort.Strings(got)
+	want = []string{"West 2022-08-01"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v want %v", got, want)
+	}
+	if accounts["west"].TimeZone != honolulu || accounts["east"].TimeZone != kiritimati {
+		t.Errorf("time zones of the users changed to %s and %s", accounts["west"].TimeZone, accounts["east"].TimeZone)
+	}
+
+	// Users without time zone are in UTC
+	unknown := map[model.Account]*pkg.User{"west": {DisplayName: "West"}}
+	timesheet, err = do(api, august, september, nil, nil, time.UTC, unknown, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timesheet) != 1 || unknown["west"].TimeZone != nil {
+		t.Errorf("got %v with time zone %s", timesheet, unknown["west"].TimeZone)
+	}
+}
+
+type fakeApi struct {
+	jql      string
+	worklogs []fakeWorklog
+	// reads is the number of worklog requests
+	reads int
+	// removed are the ids of the removed worklogs
+	removed []model.WorklogId
+}
+
+func (api *fakeApi) Me() (model.Account, *time.Location, error) {
+	return "", time.UTC, nil
+}
+
+func (api *fakeApi) User(user *pkg.User) (model.Account, *time.Location, error) {
+	return model.Account(user.DisplayName), user.TimeZone, nil
+}
+
+func (api *fakeApi) Issues(jql model.Jql, fields []string, issueFunc model.IssueFunc) error {
+	api.jql = jql.Build()
+	issueFunc(fake.Issue{IssueId: "10000", IssueKey: "PROJ-1"})
+	return nil
+}
+
+func (api *fakeApi) Worklog(key model.IssueKey, worklogFunc model.WorklogFunc) error {
+	api.reads++
+	for _, worklog := range api.worklogs {
+		worklogFunc(worklog)
+	}
+	return nil
+}
+
+func (api *fakeApi) AddWorklog(key model.IssueKey, date time.Time, duration time.Duration, description pkg.Description) (model.WorklogId, error) {
+	return "2", nil
+}
+
+func (api *fakeApi) RemoveWorklog(key model.IssueKey, id model.WorklogId) error {
+	api.removed = append(api.removed, id)
+	return nil
+}
+
+type fakeWorklog struct {
+	id      model.WorklogId
+	account model.Account
+	started time.Time
+	comment pkg.Description
+}
+
+func (worklog fakeWorklog) Id() model.WorklogId      { return worklog.id }
+func (worklog fakeWorklog) Author() model.Author     { return fakeAuthor(worklog.account) }
+func (worklog fakeWorklog) Date() time.Time          { return worklog.started }
+func (worklog fakeWorklog) Comment() pkg.Description { return worklog.comment }
+func (worklog fakeWorklog) Duration() time.Duration  { return time.Hour }
+func (worklog fakeWorklog) String() string           { return worklog.started.String() }
+
+type fakeAuthor model.Account
+
+func (author fakeAuthor) Id() model.Account { return model.Account(author) }
+func (author fakeAuthor) String() string    { return string(author) }
+
+func TestJournalApi(t *testing.T) {
+	changes := &journal.Changes{Journal: journal.New(filepath.Join(t.TempDir(), "journal.jsonl")), Batch: "b1"}
+	server, _ := url.Parse("https://jira.example.com")
+	started := time.Date(2022, time.August, 1, 9, 0, 0, 0, time.UTC)
+	fake := &fakeApi{worklogs: []fakeWorklog{{id: "1", account: "me", started: started}}}
+	api := journaled(fake, server, changes)
+
+	_, err := api.AddWorklog("PROJ-1", started, 30*time.Minute, "Fix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The worklog is already read, so the removal needs no further request
+	err = api.Worklog("PROJ-1", func(worklog model.Worklog) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = api.RemoveWorklog("PROJ-1", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.reads != 1 {
+		t.Errorf("got %d requests of worklogs want 1", fake.reads)
+	}
+	if api.RemoveWorklog("PROJ-1", "3") == nil {
+		t.Errorf("removed unknown worklog")
+	}
+
+	entries, err := changes.Journal.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []journal.Entry{
+		{Id: 1, Store: store, Server: "https://jira.example.com", Operation: journal.OperationAdd, Issue: "PROJ-1", Worklog: "2", Start: started, Duration: 30 * time.Minute, Description: "Fix", Batch: "b1"},
+		{Id: 2, Store: store, Server: "https://jira.example.com", Operation: journal.OperationRemove, Issue: "PROJ-1", Worklog: "1", Account: "me", Start: started, Duration: time.Hour, Batch: "b1"},
+	}
+	for i := range entries {
+		entries[i].Time = time.Time{}
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("got %v want %v", entries, want)
+	}
+
+	// A dry run is not recorded
+	if _, ok := journaled(fake, server, &journal.Changes{Journal: changes.Journal, DryRun: &strings.Builder{}}).(*journalApi); ok {
+		t.Errorf("got journal for a dry run")
+	}
+}
+
+func TestMappingFields(t *testing.T) {
+	// Jira Server keeps the epic inside the custom field Epic Link
+	issue := fake.Issue{IssueId: "10000", IssueKey: "PROJ-1", Fields: map[string]string{"customfield_10008": "PROJ-100"}}
+	fields := mappingFields(issue, map[string]string{"cost-center": "42"}, "customfield_10008")
+	want := pkg.MappingFields{
+		pkg.MapProject:   {"PROJ"},
+		pkg.MapIssue:     {"PROJ-1"},
+		pkg.MapEpic:      {"PROJ-100"},
+		pkg.MapComponent: nil,
+		pkg.MapLabel:     nil,
+		"cost-center":    {"42"},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("got %v want %v", fields, want)
+	}
+	fields = mappingFields(issue, nil, "")
+	if _, ok := fields[pkg.MapEpic]; ok {
+		t.Errorf("got epic %v want none", fields[pkg.MapEpic])
+	}
+}
+
+func TestUndoOtherAccount(t *testing.T) {
+	changes := &journal.Changes{Journal: journal.New(filepath.Join(t.TempDir(), "journal.jsonl")), Batch: "b2"}
+	_, err := changes.Journal.Append(journal.Entry{Store: store, Server: "https://jira.example.com", Operation: journal.OperationRemove, Issue: "PROJ-1", Worklog: "1", Account: "other", Duration: time.Hour, Batch: "b1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, _ := url.Parse("https://jira.example.com")
+	client := pkg.NewTestClient(func(request *http.Request) *http.Response {
+		body := `{"deploymentType":"Server"}`
+		switch {
+		case request.Method != http.MethodGet:
+			t.Errorf("sent %s %s", request.Method, request.URL)
+		case strings.HasSuffix(request.URL.Path, "/myself"):
+			body = `{"key":"me","timeZone":"UTC"}`
+		}
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(body)), Header: make(http.Header)}
+	})
+	_, err = Undo(client, server, nil, changes, 0)
+	if err == nil || !strings.Contains(err.Error(), "cannot be added as another user") {
+		t.Errorf("got %v want refused undo", err)
+	}
+}
+
+func TestAddWorklogItemSummarize(t *testing.T) {
+	changes := &journal.Changes{Journal: journal.New(filepath.Join(t.TempDir(), "journal.jsonl")), Batch: "b1"}
+	server, _ := url.Parse("https://jira.example.com")
+	var sent []string
+	client := pkg.NewTestClient(func(request *http.Request) *http.Response {
+		status, body := 200, `{"deploymentType":"Server"}`
+		switch {
+		case request.Method == http.MethodPost:
+			data, _ := ioutil.ReadAll(request.Body)
+			sent = append(sent, request.Method+" "+request.URL.Path+" "+string(data))
+			status, body = 201, `{"id":"3"}`
+		case request.Method == http.MethodDelete:
+			sent = append(sent, request.Method+" "+request.URL.Path)
+			status, body = 204, ``
+		case strings.HasSuffix(request.URL.Path, "/myself"):
+			body = `{"key":"me","timeZone":"UTC"}`
+		case strings.HasSuffix(request.URL.Path, "/worklog") && request.URL.Query().Get("startAt") != "0":
+			body = `{"startAt":3,"maxResults":3,"total":3,"worklogs":[]}`
+		case strings.HasSuffix(request.URL.Path, "/worklog"):
+			body = `{"startAt":0,"maxResults":3,"total":3,"worklogs":[
+{"id":"1","author":{"key":"me"},"started":"2022-08-01T09:00:00.000+0000","timeSpentSeconds":1800},
+{"id":"2","author":{"key":"me"},"started":"2022-08-01T11:00:00.000+0000","timeSpentSeconds":3600},
+{"id":"9","author":{"key":"other"},"started":"2022-08-01T11:00:00.000+0000","timeSpentSeconds":3600}]}`
+		}
+		return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader(body)), Header: make(http.Header)}
+	})
+	confirm := func(question string, items []fmt.Stringer) (bool, error) {
+		if len(items) != 2 {
+			t.Errorf("got %d worklogs to replace want 2", len(items))
+		}
+		return true, nil
+	}
+
+	err := AddWorklogItem(client, server, nil, 2022, time.August, 1, "PROJ-1", 30*time.Minute, true, internal.Rounding{}, confirm, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`POST /rest/api/2/issue/PROJ-1/worklog {"started":"2022-08-01T00:00:00.000+0000","timeSpentSeconds":7200}`,
+		"DELETE /rest/api/2/issue/PROJ-1/worklog/1",
+		"DELETE /rest/api/2/issue/PROJ-1/worklog/2",
+	}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("got %v want %v", sent, want)
+	}
+	entries, err := changes.Journal.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := journal.Batch(entries, entries[len(entries)-1]); len(got) != 3 {
+		t.Errorf("got %d entries of the batch want 3", len(got))
+	}
+}
+
+func TestRemoveWorklogs(t *testing.T) {
+	first := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 1)
+	meeting, err := pkg.Filters([]string{"description=^Meeting"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		query     model.Jql
+		selection pkg.Selection
+		jql       string
+		want      []model.WorklogId
+	}{
+		{name: "id", selection: pkg.Selection{Task: "PROJ-1", Ids: []string{"2"}}, want: []model.WorklogId{"2"}},
+		{name: "task", selection: pkg.Selection{Task: "PROJ-1"}, want: []model.WorklogId{"1", "2", "3"}},
+		{name: "range", selection: pkg.Selection{From: first, To: first}, jql: "worklogDate >= '2022/08/01' AND worklogDate < '2022/08/02' AND worklogAuthor in (\"me\")", want: []model.WorklogId{"1", "2"}},
+		{name: "filter", selection: pkg.Selection{Task: "PROJ-1", Filters: meeting}, want: []model.WorklogId{"2", "3"}},
+		{name: "jql", query: new(model.Jql).Projects("PROJ"), selection: pkg.Selection{From: second, To: second, Task: "PROJ-1"}, jql: "issuekey in (\"PROJ-1\") AND project in (\"PROJ\")", want: []model.WorklogId{"3"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := &fakeApi{worklogs: []fakeWorklog{
+				{id: "1", account: "me", started: first.Add(9 * time.Hour), comment: "Fix"},
+				{id: "2", account: "me", started: first.Add(11 * time.Hour), comment: "Meeting"},
+				{id: "3", account: "me", started: second.Add(9 * time.Hour), comment: "Meeting"},
+				{id: "4", account: "other", started: first.Add(9 * time.Hour), comment: "Meeting"},
+			}}
+			confirm := func(question string, items []fmt.Stringer) (bool, error) { return true, nil }
+			err := removeWorklogs(api, "me", time.UTC, test.query, test.selection, confirm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Slice(api.removed, func(i, j int) bool { return api.removed[i] < api.removed[j] })
+			if !reflect.DeepEqual(api.removed, test.want) {
+				t.Errorf("got %v want %v", api.removed, test.want)
+			}
+			if !strings.Contains(api.jql, test.jql) {
+				t.Errorf("got query %s want %s", api.jql, test.jql)
+			}
+		})
+	}
+}
+
+func TestRemoveWorklogsWithoutIssue(t *testing.T) {
+	api := &fakeApi{}
+	confirm := func(question string, items []fmt.Stringer) (bool, error) { return true, nil }
+	err := removeWorklogs(api, "me", time.UTC, nil, pkg.Selection{Ids: []string{"1"}}, confirm)
+	if err == nil {
+		t.Errorf("removed worklog ids without task or day")
+	}
+	if api.reads != 0 || len(api.removed) != 0 {
+		t.Errorf("got %d reads and %v removed want none", api.reads, api.removed)
+	}
+}
+
+func TestValidateTask(t *testing.T) {
+	server, _ := url.Parse("https://jira.example.com")
+	client := pkg.NewTestClient(func(request *http.Request) *http.Response {
+		if strings.HasSuffix(request.URL.Path, "/search") {
+			return &http.Response{StatusCode: 401, Status: "401 Unauthorized", Body: ioutil.NopCloser(strings.NewReader(`{"errorMessages":[]}`)), Header: http.Header{"Content-Type": {"application/json; charset=utf-8"}}}
+		}
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(`{"deploymentType":"Server"}`)), Header: make(http.Header)}
+	})
+	err := NewProvider(client, server, nil, nil).ValidateTask("PROJ-1")
+	if err == nil || !strings.Contains(err.Error(), "401 Unauthorized") {
+		t.Errorf("got %v want the failed request", err)
+	}
+}
+
+func TestProviderBatch(t *testing.T) {
+	changes := &journal.Changes{Journal: journal.New(filepath.Join(t.TempDir(), "journal.jsonl")), Batch: "b1"}
+	provider := NewProvider(nil, nil, nil, changes)
+	batched, ok := pkg.Batched(provider).(*Provider)
+	if !ok || batched.Changes.Batch == "b1" || batched.Changes.Journal != changes.Journal || provider.Changes.Batch != "b1" {
+		t.Errorf("got %v want a new batch", batched)
+	}
+}
+
+func TestAddTimesheetDryRun(t *testing.T) {
+	var out strings.Builder
+	changes := &journal.Changes{DryRun: &out}
+	server, _ := url.Parse("https://jira.example.com")
+	client := pkg.NewTestClient(func(request *http.Request) *http.Response {
+		body := `{"deploymentType":"Server"}`
+		switch {
+		case request.Method != http.MethodGet:
+			t.Errorf("sent %s %s", request.Method, request.URL)
+		case strings.HasSuffix(request.URL.Path, "/myself"):
+			body = `{"key":"me","timeZone":"UTC"}`
+		case strings.HasSuffix(request.URL.Path, "/worklog"):
+			body = `{"startAt":0,"maxResults":1,"total":1,"isLast":true,"worklogs":[
+{"id":"1","author":{"key":"me"},"started":"2022-08-01T09:00:00.000+0000","timeSpentSeconds":3600}]}`
+		}
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader(body)), Header: make(http.Header)}
+	})
+	august := func(day int) time.Time { return time.Date(2022, time.August, day, 0, 0, 0, 0, time.UTC) }
+	bookings, err := AddTimesheet(client, server, nil, pkg.Timesheet{
+		{Task: "PROJ-1", Date: august(1), Duration: time.Hour},
+		{Task: "PROJ-1", Date: august(2), Duration: time.Hour},
+	}, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bookings[0].Status != pkg.BookingDuplicate || bookings[1].Status != pkg.BookingPlanned {
+		t.Errorf("got %v want a duplicate and a planned booking", bookings)
+	}
+	if !strings.HasPrefix(out.String(), "POST https://jira.example.com/rest/api/2/issue/PROJ-1/worklog") {
+		t.Errorf("got %s want the new worklog", out.String())
+	}
+}
@@ -0,0 +1,180 @@
+package jira
+
+import (
+	"eager/pkg"
+	"eager/pkg/jira/model"
+	"eager/pkg/journal"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const store = "jira"
+
+// journalApi records every added and removed worklog inside the journal.
+type journalApi struct {
+	model.Api
+	journal *journal.Journal
+	server  string
+	batch   string
+	// undo is the id of the entry, that is reversed by the changes of this api
+	undo int
+	// read are the worklogs, that are already read, by id
+	read  map[model.WorklogId]model.Worklog
+	mutex sync.Mutex
+}
+
+// journaled returns the api, that records its changes. Changes of a dry run are not recorded.
+func journaled(api model.Api, server *url.URL, changes *journal.Changes) model.Api {
+	if !changes.Recorded() {
+		return api
+	}
+	return &journalApi{
+		Api:     api,
+		journal: changes.Journal,
+		server:  server.String(),
+		batch:   changes.Batch,
+		read:    map[model.WorklogId]model.Worklog{},
+	}
+}
+
+// Worklog keeps every worklog, so that a removal needs no request to journal it.
+func (api *journalApi) Worklog(key model.IssueKey, worklogFunc model.WorklogFunc) error {
+	return api.Api.Worklog(key, func(worklog model.Worklog) bool {
+		api.mutex.Lock()
+		api.read[worklog.Id()] = worklog
+		api.mutex.Unlock()
+		return worklogFunc(worklog)
+	})
+}
+
+func (api *journalApi) AddWorklog(key model.IssueKey, date time.Time, duration time.Duration, description pkg.Description) (model.WorklogId, error) {
+	id, err := api.Api.AddWorklog(key, date, duration, description)
+	if err != nil {
+		return id, err
+	}
+	api.record(journal.Entry{
+		Operation:   journal.OperationAdd,
+		Issue:       string(key),
+		Worklog:     string(id),
+		Start:       date,
+		Duration:    duration,
+		Description: string(description),
+	})
+	return id, nil
+}
+
+// RemoveWorklog keeps everything necessary to add the worklog again. Worklogs, that are not read yet, are read first.
+func (api *journalApi) RemoveWorklog(key model.IssueKey, id model.WorklogId) error {
+	api.mutex.Lock()
+	removed := api.read[id]
+	api.mutex.Unlock()
+	if removed == nil {
+		err := api.Worklog(key, func(worklog model.Worklog) bool {
+			return worklog.Id() != id
+		})
+		if err != nil {
+			return fmt.Errorf("could not get worklog. %s", err.Error())
+		}
+		api.mutex.Lock()
+		removed = api.read[id]
+		api.mutex.Unlock()
+	}
+	if removed == nil {
+		return fmt.Errorf("found no worklog %s of %s", id, key)
+	}
+	err := api.Api.RemoveWorklog(key, id)
+	if err != nil {
+		return err
+	}
+	entry := journal.Entry{
+		Operation:   journal.OperationRemove,
+		Issue:       string(key),
+		Worklog:     string(id),
+		Start:       removed.Date(),
+		Duration:    removed.Duration(),
+		Description: string(removed.Comment()),
+	}
+	if removed.Author() != nil {
+		entry.Account = string(removed.Author().Id())
+	}
+	api.record(entry)
+	return nil
+}
+
+// record appends the entry. The change is already done, so a broken journal only gets logged.
+func (api *journalApi) record(entry journal.Entry) {
+	entry.Store = store
+	entry.Server = api.server
+	entry.Undo = api.undo
+	entry.Batch = api.batch
+	_, err := api.journal.Append(entry)
+	if err != nil {
+		log.Println("Could not write journal.", err)
+	}
+}
+
+// Undo reverses the entry of the journal. Without id, every entry of the last command is reversed.
+// The reversals are appended to the journal and the reversed entries are returned.
+// Removed worklogs of other users are not added again, because Jira adds every worklog as the current user.
+func Undo(client *http.Client, server *url.URL, userinfo *url.Userinfo, changes *journal.Changes, id int) ([]journal.Entry, error) {
+	if changes == nil || changes.Journal == nil {
+		return nil, fmt.Errorf("there is no journal")
+	}
+	entries, err := changes.Journal.Entries()
+	if err != nil {
+		return nil, fmt.Errorf("could not read journal. %s", err.Error())
+	}
+	var batch []journal.Entry
+	if id == 0 {
+		var entry journal.Entry
+		entry, err = journal.Last(entries, store, server.String())
+		batch = journal.Batch(entries, entry)
+	} else {
+		var entry journal.Entry
+		entry, err = journal.Find(entries, store, server.String(), id)
+		batch = []journal.Entry{entry}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := getApiVersion(client, server, userinfo, changes)
+	if err != nil {
+		return nil, fmt.Errorf("could not get api version. %s", err.Error())
+	}
+	account, _, err := api.Me()
+	if err != nil {
+		return nil, fmt.Errorf("could not get user. %s", err.Error())
+	}
+	for _, entry := range batch {
+		if entry.Reverse() == journal.OperationAdd && entry.Account != "" && model.Account(entry.Account) != account {
+			return nil, fmt.Errorf("entry %d removed a worklog of %s, which cannot be added as another user", entry.Id, entry.Account)
+		}
+	}
+
+	var reversed []journal.Entry
+	for _, entry := range batch {
+		if recorder, ok := api.(*journalApi); ok {
+			recorder.undo = entry.Id
+		}
+		key := model.IssueKey(entry.Issue)
+		switch entry.Reverse() {
+		case journal.OperationRemove:
+			err = api.RemoveWorklog(key, model.WorklogId(entry.Worklog))
+			if err != nil {
+				return reversed, fmt.Errorf("could not remove effort. %s", err.Error())
+			}
+		case journal.OperationAdd:
+			_, err = api.AddWorklog(key, entry.Start, entry.Duration, pkg.Description(entry.Description))
+			if err != nil {
+				return reversed, fmt.Errorf("could not add effort. %s", err.Error())
+			}
+		}
+		reversed = append(reversed, entry)
+	}
+	return reversed, nil
+}
@@ -24,7 +24,8 @@ type UserReader interface {
 }
 
 type IssueReader interface {
-	Issues(jql Jql, issueFunc IssueFunc) error
+	// Issues calls the function for every issue of the query. The given fields are requested in addition to the default fields.
+	Issues(jql Jql, fields []string, issueFunc IssueFunc) error
 }
 
 type IssueFunc func(Issue)
@@ -38,15 +39,23 @@ type WorklogReader interface {
 }
 
 type WorklogWriter interface {
-	AddWorklog(key IssueKey, date time.Time, duration time.Duration) error
+	// AddWorklog returns the id of the new worklog
+	AddWorklog(key IssueKey, date time.Time, duration time.Duration, description pkg.Description) (WorklogId, error)
 	RemoveWorklog(key IssueKey, id WorklogId) error
 }
 
 type WorklogFunc func(Worklog) bool
 
 type Issue interface {
+	Id() string
 	Project() pkg.Project
 	Key() IssueKey
+	// Epic is the key of the parent epic or empty, if there is none
+	Epic() IssueKey
+	Components() []string
+	Labels() []string
+	// Attribute returns the text of any requested field
+	Attribute(field string) string
 	String() string
 }
 
@@ -3,18 +3,49 @@ package model
 import (
 	"eager/pkg"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
 	jqlWorklogDate    = "worklogDate >= '%s' AND worklogDate < '%s'"
-	jqlWorklogProject = "project in ('%s')"
+	jqlWorklogProject = "project in (%s)"
 	jqlWorklogAuthor  = "worklogAuthor in (%s)"
+	jqlIssueKey       = "issuekey in (%s)"
+	jqlComponent      = "component in (%s)"
+	jqlLabel          = "labels in (%s)"
+	jqlIssueType      = "issuetype in (%s)"
+	jqlSprint         = "sprint in (%s)"
+	jqlFilter         = "filter = %s"
 )
 
 type Jql []string
 
+// quote returns the values as comma separated list of JQL strings.
+func quote(values ...string) string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		value = strings.ReplaceAll(value, `"`, `\"`)
+		result[i] = `"` + value + `"`
+	}
+	return strings.Join(result, ",")
+}
+
+// quoteOrId returns the values as comma separated list of JQL strings, numeric ids are kept unquoted.
+func quoteOrId(values ...string) string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		if _, err := strconv.ParseUint(value, 10, 64); err == nil {
+			result[i] = value
+			continue
+		}
+		result[i] = quote(value)
+	}
+	return strings.Join(result, ",")
+}
+
 func (query Jql) Projects(projects ...pkg.Project) Jql {
 	if len(projects) == 0 {
 		return query
@@ -23,7 +54,7 @@ func (query Jql) Projects(projects ...pkg.Project) Jql {
 	for i, project := range projects {
 		result[i] = string(project)
 	}
-	return append(query, fmt.Sprintf(jqlWorklogProject, strings.Join(result, "','")))
+	return append(query, fmt.Sprintf(jqlWorklogProject, quote(result...)))
 }
 
 func (query Jql) Users(users ...Account) Jql {
@@ -34,7 +65,69 @@ func (query Jql) Users(users ...Account) Jql {
 	for i, user := range users {
 		result[i] = string(user)
 	}
-	return append(query, fmt.Sprintf(jqlWorklogAuthor, "'"+strings.Join(result, "','")+"'"))
+	return append(query, fmt.Sprintf(jqlWorklogAuthor, quote(result...)))
+}
+
+// Keys restricts the query to the issues given by key or id.
+func (query Jql) Keys(keys ...IssueKey) Jql {
+	if len(keys) == 0 {
+		return query
+	}
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		result[i] = string(key)
+	}
+	return append(query, fmt.Sprintf(jqlIssueKey, quoteOrId(result...)))
+}
+
+func (query Jql) Components(components ...string) Jql {
+	if len(components) == 0 {
+		return query
+	}
+	return append(query, fmt.Sprintf(jqlComponent, quote(components...)))
+}
+
+func (query Jql) Labels(labels ...string) Jql {
+	if len(labels) == 0 {
+		return query
+	}
+	return append(query, fmt.Sprintf(jqlLabel, quote(labels...)))
+}
+
+func (query Jql) IssueTypes(types ...string) Jql {
+	if len(types) == 0 {
+		return query
+	}
+	return append(query, fmt.Sprintf(jqlIssueType, quote(types...)))
+}
+
+// Sprints restricts the query to the sprints given by id or name.
+func (query Jql) Sprints(sprints ...string) Jql {
+	if len(sprints) == 0 {
+		return query
+	}
+	return append(query, fmt.Sprintf(jqlSprint, quoteOrId(sprints...)))
+}
+
+// Filter restricts the query to the issues of the saved filter with the given id or name.
+func (query Jql) Filter(filter string) Jql {
+	if filter == "" {
+		return query
+	}
+	return append(query, fmt.Sprintf(jqlFilter, quoteOrId(filter)))
+}
+
+// Query adds a custom JQL query, which is combined with the other conditions.
+func (query Jql) Query(jql string) Jql {
+	if strings.TrimSpace(jql) == "" {
+		return query
+	}
+	return append(query, "("+jql+")")
+}
+
+// And combines both queries.
+func (query Jql) And(other Jql) Jql {
+	return append(append(Jql{}, query...), other...)
 }
 
 func (query Jql) Between(fromDate, toDate time.Time) Jql {
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJql(t *testing.T) {
+	date := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query Jql
+		want  string
+	}{
+		{name: "empty", query: new(Jql).Projects().Labels().Query(" "), want: ""},
+		{name: "projects", query: new(Jql).Projects("A", "B"), want: `project in ("A","B")`},
+		{name: "escape", query: new(Jql).Labels(`it's "quoted"`, `back\slash`), want: `labels in ("it's \"quoted\"","back\\slash")`},
+		{name: "combined", query: new(Jql).Between(date, date.AddDate(0, 1, 0)).Filter("12345").Query("status = Done OR priority = High"),
+			want: `worklogDate >= '2022/08/01' AND worklogDate < '2022/09/01' AND filter = 12345 AND (status = Done OR priority = High)`},
+		{name: "types and sprints", query: new(Jql).IssueTypes("Bug").Sprints("42", "Sprint 7").Components("Backend"),
+			want: `issuetype in ("Bug") AND sprint in (42,"Sprint 7") AND component in ("Backend")`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.query.Build(); got != test.want {
+				t.Errorf("got %s want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"eager/pkg"
+	"eager/pkg/jira/model"
+	"eager/pkg/journal"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Provider is the worklog of the current user inside Jira.
+type Provider struct {
+	Client   *http.Client
+	Server   *url.URL
+	Userinfo *url.Userinfo
+	// Changes tell, how worklogs are changed
+	Changes *journal.Changes
+}
+
+func NewProvider(client *http.Client, server *url.URL, userinfo *url.Userinfo, changes *journal.Changes) *Provider {
+	return &Provider{
+		Client:   client,
+		Server:   server,
+		Userinfo: userinfo,
+		Changes:  changes,
+	}
+}
+
+// Batch returns the provider, that records its changes inside a new batch of the journal.
+func (provider *Provider) Batch() pkg.Provider {
+	batched := *provider
+	batched.Changes = provider.Changes.Next()
+	return &batched
+}
+
+func (provider *Provider) Timesheet(year int, month time.Month) (pkg.Timesheet, error) {
+	api, err := getApiVersion(provider.Client, provider.Server, provider.Userinfo, provider.Changes)
+	if err != nil {
+		return nil, fmt.Errorf("could not get api version. %s", err.Error())
+	}
+	account, location, err := api.Me()
+	if err != nil {
+		return nil, fmt.Errorf("could not get user. %s", err.Error())
+	}
+	accounts := map[model.Account]*pkg.User{account: {TimeZone: location}}
+	fromDate, toDate := pkg.GetTimeRange(year, month)
+	return do(api, fromDate, toDate, nil, nil, location, accounts, nil, nil, nil)
+}
+
+func (provider *Provider) BulkTimesheet(year int, month time.Month, users []*pkg.User) (pkg.Timesheet, error) {
+	api, err := getApiVersion(provider.Client, provider.Server, provider.Userinfo, provider.Changes)
+	if err != nil {
+		return nil, fmt.Errorf("could not get api version. %s", err.Error())
+	}
+	_, location, err := api.Me()
+	if err != nil {
+		return nil, fmt.Errorf("could not get user. %s", err.Error())
+	}
+	accounts, err := accounts(api, users)
+	if err != nil {
+		return nil, fmt.Errorf("could not get user. %s", err.Error())
+	}
+	fromDate, toDate := pkg.GetTimeRange(year, month)
+	return do(api, fromDate, toDate, nil, nil, location, accounts, nil, nil, nil)
+}
+
+func (provider *Provider) Add(effort pkg.Effort) (string, error) {
+	api, err := getApiVersion(provider.Client, provider.Server, provider.Userinfo, provider.Changes)
+	if err != nil {
+		return "", fmt.Errorf("could not get api version. %s", err.Error())
+	}
+	_, location, err := api.Me()
+	if err != nil {
+		return "", fmt.Errorf("could not get user. %s", err.Error())
+	}
+	start := effort.Start
+	if start.IsZero() {
+		year, month, day := effort.Date.Date()
+		start = AdjustDateTime(location, effort.Duration, year, month, day)
+	}
+	id, err := api.AddWorklog(model.IssueKey(effort.Task), start, effort.Duration, effort.Description)
+	if err != nil {
+		return "", fmt.Errorf("could not add effort. %s", err.Error())
+	}
+	return string(id), nil
+}
+
+// ValidateTask checks, that the issue of the task exists and is visible to the current user.
+func (provider *Provider) ValidateTask(task pkg.Task) error {
+	api, err := getApiVersion(provider.Client, provider.Server, provider.Userinfo, provider.Changes)
+	if err != nil {
+		return fmt.Errorf("could not get api version. %s", err.Error())
+	}
+	found := false
+	err = api.Issues(model.Jql{}.Keys(model.IssueKey(task)), nil, func(issue model.Issue) {
+		found = true
+	})
+	// Jira rejects the query of an unknown issue, but the request may fail for other reasons too
+	if err != nil {
+		return fmt.Errorf("could not find issue %s. %s", task, err.Error())
+	}
+	if !found {
+		return fmt.Errorf("unknown issue %s", task)
+	}
+	return nil
+}
+
+func (provider *Provider) Remove(effort pkg.Effort) error {
+	if effort.Id == "" {
+		return fmt.Errorf("effort of %s has no id", effort.Task)
+	}
+	api, err := getApiVersion(provider.Client, provider.Server, provider.Userinfo, provider.Changes)
+	if err != nil {
+		return fmt.Errorf("could not get api version. %s", err.Error())
+	}
+	err = api.RemoveWorklog(model.IssueKey(effort.Task), model.WorklogId(effort.Id))
+	if err != nil {
+		return fmt.Errorf("could not remove effort. %s", err.Error())
+	}
+	return nil
+}
@@ -7,11 +7,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"golang.org/x/net/html/charset"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -30,6 +32,10 @@ type Api struct {
 	Client   *http.Client
 	Server   *url.URL
 	Userinfo *url.Userinfo
+	// Document writes comments in the Atlassian document format of API version 3
+	Document bool
+	// DryRun prints every change instead of sending it, if it is set
+	DryRun io.Writer
 }
 
 func (api Api) Me() (model.Account, *time.Location, error) {
@@ -59,7 +65,7 @@ func (api Api) Me() (model.Account, *time.Location, error) {
 	if err != nil {
 		return "", nil, err
 	}
-	return result.AccountId, result.Location(), nil
+	return result.Account(), result.Location(), nil
 }
 
 func (api Api) User(user *pkg.User) (model.Account, *time.Location, error) {
@@ -99,7 +105,7 @@ func (api Api) User(user *pkg.User) (model.Account, *time.Location, error) {
 		if err != nil {
 			return "", nil, err
 		}
-		return result.AccountId, result.Location(), nil
+		return result.Account(), result.Location(), nil
 	}
 
 	var result = make([]userQueryResult, 0, 2)
@@ -113,16 +119,16 @@ func (api Api) User(user *pkg.User) (model.Account, *time.Location, error) {
 	if len(result) > 1 && user.Matches(pkg.User{DisplayName: result[1].DisplayName}) {
 		return "", nil, fmt.Errorf("found more than one user for %s", user.DisplayName)
 	}
-	return result[0].AccountId, result[0].Location(), nil
+	return result[0].Account(), result[0].Location(), nil
 }
 
-func (api Api) Issues(jql model.Jql, issueFunc model.IssueFunc) error {
-	return api.issues(jql, 0, issueFunc)
+func (api Api) Issues(jql model.Jql, fields []string, issueFunc model.IssueFunc) error {
+	return api.issues(jql, append([]string{"project", "components", "labels", "parent"}, fields...), 0, issueFunc)
 }
 
-func (api Api) issues(jql model.Jql, startAt int, issueFunc model.IssueFunc) error {
+func (api Api) issues(jql model.Jql, fields []string, startAt int, issueFunc model.IssueFunc) error {
 	body, _ := json.Marshal(issueQuery{
-		Fields:         []string{"project"},
+		Fields:         fields,
 		Jql:            jql.Build(),
 		PaginatedQuery: &PaginatedQuery{StartAt: startAt},
 	})
@@ -157,7 +163,7 @@ func (api Api) issues(jql model.Jql, startAt int, issueFunc model.IssueFunc) err
 		issueFunc(e)
 	}
 	if (result.IsLast == nil && result.Total >= startAt+result.MaxResults) || (result.IsLast != nil && !*result.IsLast) {
-		err = api.issues(jql, startAt+result.MaxResults, issueFunc)
+		err = api.issues(jql, fields, startAt+result.MaxResults, issueFunc)
 	}
 	return err
 }
@@ -200,15 +206,26 @@ func (api Api) worklog(key model.IssueKey, startAt int, worklogFunc model.Worklo
 	return err
 }
 
-func (api Api) AddWorklog(key model.IssueKey, date time.Time, duration time.Duration) error {
-	body, _ := json.Marshal(worklogItem{
+func (api Api) AddWorklog(key model.IssueKey, date time.Time, duration time.Duration, description pkg.Description) (model.WorklogId, error) {
+	item := worklogItem{
 		Started:          date.Format(pkg.IsoDateTime),
 		TimeSpentSeconds: int(duration.Truncate(time.Second).Seconds()),
-	})
+	}
+	if description != "" {
+		item.ApiComment = &comment{Text: string(description)}
+		if api.Document {
+			item.ApiComment.Document = newDocument(string(description))
+		}
+	}
+	body, _ := json.Marshal(item)
 	worklogUrl, err := api.Server.Parse(fmt.Sprintf(addWorklogUrl, string(key)))
+	if api.DryRun != nil {
+		pkg.PrintRequest(api.DryRun, http.MethodPost, worklogUrl, body)
+		return "", nil
+	}
 	response, err := pkg.CreateJsonRequest(api.Client, http.MethodPost, worklogUrl, api.Userinfo, bytes.NewBuffer(body))
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer func() {
 		err := response.Body.Close()
@@ -218,14 +235,27 @@ func (api Api) AddWorklog(key model.IssueKey, date time.Time, duration time.Dura
 	}()
 
 	if response.StatusCode != 201 {
-		return fmt.Errorf(response.Status)
+		return "", fmt.Errorf(response.Status)
 	}
 
-	return nil
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	var result worklogItem
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return "", err
+	}
+	return model.WorklogId(result.ApiId), nil
 }
 
 func (api Api) RemoveWorklog(key model.IssueKey, id model.WorklogId) error {
 	worklogUrl, err := api.Server.Parse(fmt.Sprintf(removeWorklogUrl, string(key), string(id)))
+	if api.DryRun != nil {
+		pkg.PrintRequest(api.DryRun, http.MethodDelete, worklogUrl, nil)
+		return nil
+	}
 	response, err := pkg.CreateJsonRequest(api.Client, http.MethodDelete, worklogUrl, api.Userinfo, nil)
 	if err != nil {
 		return err
@@ -260,6 +290,10 @@ func (result worklogQueryResult) worklogs() []model.Worklog {
 	return worklogs
 }
 
+func (issue issue) Id() string {
+	return issue.ApiId
+}
+
 func (issue issue) Project() pkg.Project {
 	return pkg.Project(issue.Fields.Project.Key)
 }
@@ -268,11 +302,78 @@ func (issue issue) Key() model.IssueKey {
 	return issue.ApiKey
 }
 
+func (issue issue) Epic() model.IssueKey {
+	parent := issue.Fields.Parent
+	if parent == nil || parent.Fields == nil || parent.Fields.IssueType == nil || parent.Fields.IssueType.Name != "Epic" {
+		return ""
+	}
+	return parent.Key
+}
+
+func (issue issue) Components() []string {
+	components := make([]string, len(issue.Fields.Components))
+	for i, component := range issue.Fields.Components {
+		components[i] = component.Name
+	}
+	return components
+}
+
+func (issue issue) Labels() []string {
+	return issue.Fields.Labels
+}
+
+func (issue issue) Attribute(field string) string {
+	return attribute(issue.raw[field])
+}
+
+// attribute returns the text of a field value.
+// Objects are represented by their value, name or key, arrays by the comma separated text of their items.
+func attribute(data json.RawMessage) string {
+	if len(data) == 0 {
+		return ""
+	}
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return ""
+	}
+	return text(value)
+}
+
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if t := text(item); t != "" {
+				items = append(items, t)
+			}
+		}
+		return strings.Join(items, ", ")
+	case map[string]interface{}:
+		for _, key := range []string{"value", "name", "displayName", "key"} {
+			if item, ok := v[key]; ok {
+				return text(item)
+			}
+		}
+	}
+	return ""
+}
+
 func (issue issue) String() string {
 	return fmt.Sprintf("%s;%s", issue.Project(), issue.Key())
 }
 
 func (author author) Id() model.Account {
+	if author.AccountId == "" {
+		return author.Key
+	}
 	return author.AccountId
 }
 
@@ -298,11 +399,10 @@ func (effort worklogItem) Date() time.Time {
 }
 
 func (effort worklogItem) Comment() pkg.Description {
-	description := ""
-	if effort.ApiComment != nil && len(effort.ApiComment.Content) > 0 && len(effort.ApiComment.Content[0].Content) > 0 {
-		description = effort.ApiComment.Content[0].Content[0].Text
+	if effort.ApiComment == nil {
+		return ""
 	}
-	return pkg.Description(description)
+	return pkg.Description(effort.ApiComment.Text)
 }
 
 func (effort worklogItem) Duration() time.Duration {
@@ -0,0 +1,35 @@
+package v2
+
+import (
+	"bytes"
+	"eager/pkg"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestDryRun(t *testing.T) {
+	server, _ := url.Parse("https://jira.example.com/rest/api/2/")
+	client := pkg.NewTestClient(func(req *http.Request) *http.Response {
+		t.Errorf("sent %s %s", req.Method, req.URL)
+		return &http.Response{StatusCode: 500, Body: http.NoBody}
+	})
+	var out bytes.Buffer
+	api := Api{Client: client, Server: server, Userinfo: url.UserPassword("user", "password"), DryRun: &out}
+
+	id, err := api.AddWorklog("PROJ-1", time.Date(2022, 8, 1, 9, 30, 0, 0, time.UTC), time.Hour, "Fix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(id), "")
+	err = api.RemoveWorklog("PROJ-1", "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, out.String(), `POST https://jira.example.com/rest/api/2/issue/PROJ-1/worklog?notifyUsers=false&adjustEstimate=leave
+{"comment":"Fix","started":"2022-08-01T09:30:00.000+0000","timeSpentSeconds":3600}
+DELETE https://jira.example.com/rest/api/2/issue/PROJ-1/worklog/42?notifyUsers=false&adjustEstimate=leave
+`)
+}
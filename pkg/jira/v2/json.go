@@ -2,6 +2,7 @@ package v2
 
 import (
 	"eager/pkg/jira/model"
+	"encoding/json"
 	"time"
 )
 
@@ -19,24 +20,38 @@ type PaginatedResult struct {
 }
 
 type userQueryResult struct {
-	AccountId   model.Account `json:"accountId"`
+	AccountId model.Account `json:"accountId"`
+	// Key is the identifier of the user for Jira Server, which has no account ids
+	Key         model.Account `json:"key"`
 	DisplayName string        `json:"displayName"`
 	TimeZone    string        `json:"timeZone"`
 }
 
+func (result userQueryResult) Account() model.Account {
+	if result.AccountId == "" {
+		return result.Key
+	}
+	return result.AccountId
+}
+
+// Location returns the time zone of the user or UTC, if the time zone is unknown.
 func (result userQueryResult) Location() *time.Location {
-	location, _ := time.LoadLocation(result.TimeZone)
+	location, err := time.LoadLocation(result.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
 	return location
 }
 
 type author struct {
 	AccountId    model.Account `json:"accountId"`
+	Key          model.Account `json:"key"`
 	EmailAddress string        `json:"emailAddress"`
 	DisplayName  string        `json:"displayName"`
 }
 
 type issue struct {
-	Id     string         `json:"id"`
+	ApiId  string         `json:"id"`
 	ApiKey model.IssueKey `json:"key"`
 	Fields *struct {
 		Project *struct {
@@ -44,7 +59,38 @@ type issue struct {
 			Key  projectKey `json:"key"`
 			Name string     `json:"name"`
 		} `json:"project"`
+		Components []*struct {
+			Name string `json:"name"`
+		} `json:"components,omitempty"`
+		Labels []string `json:"labels,omitempty"`
+		Parent *struct {
+			Key    model.IssueKey `json:"key"`
+			Fields *struct {
+				IssueType *struct {
+					Name string `json:"name"`
+				} `json:"issuetype,omitempty"`
+			} `json:"fields,omitempty"`
+		} `json:"parent,omitempty"`
 	} `json:"fields"`
+	// raw contains every field of the issue
+	raw map[string]json.RawMessage
+}
+
+func (result *issue) UnmarshalJSON(data []byte) error {
+	type plain issue
+	err := json.Unmarshal(data, (*plain)(result))
+	if err != nil {
+		return err
+	}
+	var raw struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	result.raw = raw.Fields
+	return nil
 }
 
 type issueQuery struct {
@@ -64,20 +110,61 @@ type worklogQueryResult struct {
 }
 
 type worklogItem struct {
-	ApiId        string  `json:"id,omitempty"`
-	ApiAuthor    *author `json:"author,omitempty"`
-	UpdateAuthor *author `json:"updateAuthor,omitempty"`
-	ApiComment   *struct {
-		Type    string `json:"type,omitempty"`
-		Version int    `json:"version,omitempty"`
-		Content []*struct {
-			Type    string `json:"type,omitempty"`
-			Content []*struct {
-				Type string `json:"type,omitempty"`
-				Text string `json:"text,omitempty"`
-			} `json:"content,omitempty"`
-		} `json:"content,omitempty"`
-	} `json:"comment,omitempty"`
-	Started          string `json:"started,omitempty"`
-	TimeSpentSeconds int    `json:"timeSpentSeconds,omitempty"`
+	ApiId            string   `json:"id,omitempty"`
+	ApiAuthor        *author  `json:"author,omitempty"`
+	UpdateAuthor     *author  `json:"updateAuthor,omitempty"`
+	ApiComment       *comment `json:"comment,omitempty"`
+	Started          string   `json:"started,omitempty"`
+	TimeSpentSeconds int      `json:"timeSpentSeconds,omitempty"`
+}
+
+// comment is either a plain text (API version 2) or a document in the Atlassian document format (API version 3).
+type comment struct {
+	Text     string    `json:"-"`
+	Document *document `json:"-"`
+}
+
+type document struct {
+	Type    string  `json:"type,omitempty"`
+	Version int     `json:"version,omitempty"`
+	Content []*node `json:"content,omitempty"`
+}
+
+type node struct {
+	Type    string  `json:"type,omitempty"`
+	Text    string  `json:"text,omitempty"`
+	Content []*node `json:"content,omitempty"`
+}
+
+func newDocument(text string) *document {
+	return &document{
+		Type:    "doc",
+		Version: 1,
+		Content: []*node{{
+			Type:    "paragraph",
+			Content: []*node{{Type: "text", Text: text}},
+		}},
+	}
+}
+
+func (c *comment) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &c.Text)
+	}
+	c.Document = &document{}
+	err := json.Unmarshal(data, c.Document)
+	if err != nil {
+		return err
+	}
+	if len(c.Document.Content) > 0 && len(c.Document.Content[0].Content) > 0 {
+		c.Text = c.Document.Content[0].Content[0].Text
+	}
+	return nil
+}
+
+func (c comment) MarshalJSON() ([]byte, error) {
+	if c.Document != nil {
+		return json.Marshal(c.Document)
+	}
+	return json.Marshal(c.Text)
 }
@@ -0,0 +1,39 @@
+package v2
+
+import (
+	"eager/pkg/jira/model"
+	"encoding/json"
+	"testing"
+)
+
+func TestAccount(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want model.Account
+	}{
+		{name: "cloud", data: `{"accountId":"5b10ac8d82e05b22cc7d4ef5","key":"","displayName":"Jane"}`, want: "5b10ac8d82e05b22cc7d4ef5"},
+		{name: "server", data: `{"key":"JIRAUSER10100","name":"jane","displayName":"Jane"}`, want: "JIRAUSER10100"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var user userQueryResult
+			err := json.Unmarshal([]byte(test.data), &user)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := user.Account(); got != test.want {
+				t.Errorf("got %s want %s", got, test.want)
+			}
+			// The author of a worklog must match the account of the user
+			var worklogAuthor author
+			err = json.Unmarshal([]byte(test.data), &worklogAuthor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := worklogAuthor.Id(); got != test.want {
+				t.Errorf("got author %s want %s", got, test.want)
+			}
+		})
+	}
+}
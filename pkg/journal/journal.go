@@ -0,0 +1,296 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Operation is the kind of change of a worklog.
+type Operation string
+
+const (
+	OperationAdd    Operation = "add"
+	OperationRemove Operation = "remove"
+)
+
+// Entry is a change of a worklog with everything necessary to reverse it.
+type Entry struct {
+	Id   int       `json:"id"`
+	Time time.Time `json:"time"`
+	// Store is the kind of store like jira or tempo
+	Store       string            `json:"store"`
+	Server      string            `json:"server"`
+	Operation   Operation         `json:"operation"`
+	Issue       string            `json:"issue"`
+	Worklog     string            `json:"worklog,omitempty"`
+	Account     string            `json:"account,omitempty"`
+	Start       time.Time         `json:"start"`
+	Duration    time.Duration     `json:"duration"`
+	Billable    time.Duration     `json:"billable,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	// Undo is the id of the entry, that is reversed by this entry
+	Undo int `json:"undo,omitempty"`
+	// Batch groups the entries of one command invocation
+	Batch string `json:"batch,omitempty"`
+}
+
+// Journal is a local file, that every entry is appended to. Entries are never changed.
+type Journal struct {
+	Path  string
+	mutex sync.Mutex
+}
+
+// lockTimeout is the time to wait for the lock of another process. An older lock is left over by a crashed process.
+const lockTimeout = 10 * time.Second
+
+// Changes tell, how the worklog is changed by one command invocation.
+// A dry run prints every change instead of sending it, otherwise every change is recorded inside the journal, if there is one.
+type Changes struct {
+	Journal *Journal
+	DryRun  io.Writer
+	// Batch is the batch of every recorded entry
+	Batch string
+}
+
+// NewBatch returns a new batch for the entries of a command invocation.
+func NewBatch() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// Next returns the changes with a new batch, e.g. for every request of a long-running process.
+func (changes *Changes) Next() *Changes {
+	if changes == nil {
+		return nil
+	}
+	next := *changes
+	next.Batch = NewBatch()
+	return &next
+}
+
+// Recorded tells, if the changes are recorded inside the journal.
+func (changes *Changes) Recorded() bool {
+	return changes != nil && changes.Journal != nil && changes.DryRun == nil
+}
+
+// Writer returns the writer of a dry run or nil, if the changes are sent.
+func (changes *Changes) Writer() io.Writer {
+	if changes == nil {
+		return nil
+	}
+	return changes.DryRun
+}
+
+func New(path string) *Journal {
+	return &Journal{Path: path}
+}
+
+// DefaultPath is the journal inside the configuration directory of the user.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "eager", "journal.jsonl")
+}
+
+// Append writes the entry with the next id and the current time to the end of the journal.
+// Other processes may append to the journal too, so the last id is read under a lock of the journal.
+func (journal *Journal) Append(entry Entry) (Entry, error) {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+	err := os.MkdirAll(filepath.Dir(journal.Path), 0700)
+	if err != nil {
+		return entry, err
+	}
+	unlock, err := journal.lock()
+	if err != nil {
+		return entry, err
+	}
+	defer unlock()
+
+	last, err := journal.lastId()
+	if err != nil {
+		return entry, err
+	}
+	entry.Id = last + 1
+	entry.Time = time.Now()
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return entry, err
+	}
+	file, err := os.OpenFile(journal.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return entry, err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		_ = file.Close()
+		return entry, err
+	}
+	return entry, file.Close()
+}
+
+// lock creates the lock file of the journal and returns the function to remove it.
+// The lock file works on every platform, a lock older than the timeout is taken over.
+func (journal *Journal) lock() (func(), error) {
+	path := journal.Path + ".lock"
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = file.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("could not lock the journal. %s", err.Error())
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockTimeout {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Since(start) > lockTimeout {
+			return nil, fmt.Errorf("could not lock the journal, remove %s, if no other eager is running", path)
+		}
+	}
+}
+
+// lastId returns the id of the last entry or 0 for an empty journal. Only the end of the file is read.
+func (journal *Journal) lastId() (int, error) {
+	file, err := os.Open(journal.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	for chunk := int64(4096); ; chunk *= 2 {
+		offset := size - chunk
+		if offset < 0 {
+			offset = 0
+		}
+		data := make([]byte, size-offset)
+		_, err = file.ReadAt(data, offset)
+		if err != nil {
+			return 0, err
+		}
+		data = bytes.TrimSpace(data)
+		line := bytes.LastIndexByte(data, '\n')
+		if line < 0 && offset > 0 {
+			// The last line starts before the chunk
+			continue
+		}
+		if len(data) == 0 {
+			return 0, nil
+		}
+		var entry Entry
+		err = json.Unmarshal(data[line+1:], &entry)
+		if err != nil {
+			return 0, fmt.Errorf("not a valid last journal entry. %s", err.Error())
+		}
+		return entry.Id, nil
+	}
+}
+
+// Entries returns every entry in the order of the journal. A missing journal has no entries.
+func (journal *Journal) Entries() ([]Entry, error) {
+	file, err := os.Open(journal.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("not a valid journal entry in line %d. %s", line, err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Find returns the entry with the id of the store and server.
+func Find(entries []Entry, store, server string, id int) (Entry, error) {
+	for _, entry := range entries {
+		if entry.Id != id {
+			continue
+		}
+		if entry.Store != store || entry.Server != server {
+			return Entry{}, fmt.Errorf("entry %d belongs to %s %s", id, entry.Store, entry.Server)
+		}
+		if undo := UndoneBy(entries, id); undo != 0 {
+			return Entry{}, fmt.Errorf("entry %d is already reversed by %d", id, undo)
+		}
+		return entry, nil
+	}
+	return Entry{}, fmt.Errorf("found no entry %d", id)
+}
+
+// Last returns the last entry of the store and server, that is neither reversed nor reverses another entry itself.
+func Last(entries []Entry, store, server string) (Entry, error) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.Store == store && entry.Server == server && entry.Undo == 0 && UndoneBy(entries, entry.Id) == 0 {
+			return entry, nil
+		}
+	}
+	return Entry{}, fmt.Errorf("found no operation to undo")
+}
+
+// Batch returns the entries of the batch of the entry, that are neither reversed nor reverse another entry, the latest first.
+// Entries without batch are a batch of their own.
+func Batch(entries []Entry, entry Entry) []Entry {
+	if entry.Batch == "" {
+		return []Entry{entry}
+	}
+	var result []Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Batch == entry.Batch && e.Store == entry.Store && e.Server == entry.Server && e.Undo == 0 && UndoneBy(entries, e.Id) == 0 {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// UndoneBy returns the id of the entry, that reverses the entry with the id, or 0.
+func UndoneBy(entries []Entry, id int) int {
+	for _, entry := range entries {
+		if entry.Undo == id {
+			return entry.Id
+		}
+	}
+	return 0
+}
+
+// Reverse returns the operation, that reverses the entry.
+func (entry Entry) Reverse() Operation {
+	if entry.Operation == OperationAdd {
+		return OperationRemove
+	}
+	return OperationAdd
+}
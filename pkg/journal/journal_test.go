@@ -0,0 +1,129 @@
+package journal
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJournal(t *testing.T) {
+	journal := New(filepath.Join(t.TempDir(), "eager", "journal.jsonl"))
+	entries, err := journal.Entries()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("got %v, %v want no entries", entries, err)
+	}
+
+	start := time.Date(2022, 8, 1, 9, 0, 0, 0, time.UTC)
+	for _, entry := range []Entry{
+		{Store: "jira", Server: "https://a", Operation: OperationAdd, Issue: "PROJ-1", Worklog: "10", Start: start, Duration: time.Hour},
+		{Store: "jira", Server: "https://a", Operation: OperationRemove, Issue: "PROJ-2", Worklog: "11", Start: start, Duration: time.Hour},
+		{Store: "jira", Server: "https://b", Operation: OperationRemove, Issue: "PROJ-3", Worklog: "12", Start: start, Duration: time.Hour},
+		{Store: "jira", Server: "https://a", Operation: OperationAdd, Issue: "PROJ-2", Worklog: "13", Start: start, Duration: time.Hour, Undo: 2},
+	} {
+		_, err = journal.Append(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err = journal.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 || entries[3].Id != 4 || !entries[1].Start.Equal(start) {
+		t.Fatalf("got %v", entries)
+	}
+
+	last, err := Last(entries, "jira", "https://a")
+	if err != nil || last.Id != 1 {
+		t.Errorf("got %d, %v want 1", last.Id, err)
+	}
+	if _, err = Find(entries, "jira", "https://a", 2); err == nil {
+		t.Errorf("reversed entry found")
+	}
+	if _, err = Find(entries, "jira", "https://a", 3); err == nil {
+		t.Errorf("entry of other server found")
+	}
+	if got := UndoneBy(entries, 2); got != 4 {
+		t.Errorf("got %d want 4", got)
+	}
+	if got := entries[1].Reverse(); got != OperationAdd {
+		t.Errorf("got %s want %s", got, OperationAdd)
+	}
+
+	// Another journal of the same file continues with the next id
+	entry, err := New(journal.Path).Append(Entry{Store: "jira", Server: "https://a", Operation: OperationAdd, Issue: "PROJ-4", Description: strings.Repeat("x", 5000)})
+	if err != nil || entry.Id != 5 {
+		t.Errorf("got %d, %v want 5", entry.Id, err)
+	}
+	entry, err = New(journal.Path).Append(Entry{Store: "jira", Server: "https://a", Operation: OperationAdd, Issue: "PROJ-5"})
+	if err != nil || entry.Id != 6 {
+		t.Errorf("got %d, %v want 6", entry.Id, err)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	entries := []Entry{
+		{Id: 1, Store: "jira", Server: "https://a", Operation: OperationAdd, Batch: "a"},
+		{Id: 2, Store: "jira", Server: "https://a", Operation: OperationAdd, Batch: "b"},
+		{Id: 3, Store: "jira", Server: "https://a", Operation: OperationRemove, Batch: "b"},
+		{Id: 4, Store: "jira", Server: "https://a", Operation: OperationAdd, Batch: "b"},
+		{Id: 5, Store: "jira", Server: "https://a", Operation: OperationRemove, Batch: "c", Undo: 4},
+		{Id: 6, Store: "jira", Server: "https://a", Operation: OperationAdd},
+	}
+	var got []int
+	for _, entry := range Batch(entries, entries[1]) {
+		got = append(got, entry.Id)
+	}
+	if !reflect.DeepEqual(got, []int{3, 2}) {
+		t.Errorf("got %v want [3 2]", got)
+	}
+	if batch := Batch(entries, entries[5]); len(batch) != 1 || batch[0].Id != 6 {
+		t.Errorf("got %v want only entry 6", batch)
+	}
+}
+
+func TestAppendOfProcesses(t *testing.T) {
+	// Every process has its own journal of the same file
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	journals := []*Journal{New(path), New(path)}
+	var wg sync.WaitGroup
+	for _, journal := range journals {
+		wg.Add(1)
+		go func(journal *Journal) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				_, err := journal.Append(Entry{Store: "jira", Operation: OperationAdd})
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		}(journal)
+	}
+	wg.Wait()
+	entries, err := journals[0].Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, entry := range entries {
+		if entry.Id != i+1 {
+			t.Fatalf("got id %d want %d", entry.Id, i+1)
+		}
+	}
+	if len(entries) != 40 {
+		t.Errorf("got %d entries want 40", len(entries))
+	}
+}
+
+func TestNext(t *testing.T) {
+	changes := &Changes{Journal: New("journal.jsonl"), Batch: "b1"}
+	next := changes.Next()
+	if next.Batch == "" || next.Batch == changes.Batch || next.Journal != changes.Journal || changes.Batch != "b1" {
+		t.Errorf("got %v want a new batch of %v", next, changes)
+	}
+	if (*Changes)(nil).Next() != nil {
+		t.Errorf("got changes of nil")
+	}
+}
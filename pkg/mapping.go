@@ -0,0 +1,163 @@
+package pkg
+
+import (
+	"eager/internal"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	MapProject   = "project"
+	MapIssue     = "issue"
+	MapEpic      = "epic"
+	MapComponent = "component"
+	MapLabel     = "label"
+)
+
+// MappingFields are the values of an issue to match, e.g. the labels with the key "label".
+type MappingFields map[string][]string
+
+// Mapping maps the issues of one store to the project and task of another store.
+// The explicit lookup table takes precedence over the rules, the first matching rule wins.
+type Mapping struct {
+	table    map[string]MappingTarget
+	rules    []mappingRule
+	epicLink string
+}
+
+type MappingTarget struct {
+	Project Project
+	Task    Task
+}
+
+// MappingMatch is the target of the matching table entry or rule.
+type MappingMatch struct {
+	MappingTarget
+	// Rule describes the table entry or rule, that matched
+	Rule string
+}
+
+type mappingRule struct {
+	field   string
+	pattern *regexp.Regexp
+	MappingTarget
+	description string
+}
+
+// NewMapping returns the mapping of the configuration.
+// The field of every rule is a built-in field or the name of one of the attributes, which must not hide a built-in field.
+func NewMapping(config internal.Mapping, attributes []string) (*Mapping, error) {
+	fields := map[string]bool{}
+	for _, field := range []string{MapProject, MapIssue, MapEpic, MapComponent, MapLabel} {
+		fields[field] = true
+	}
+	for _, attribute := range attributes {
+		if fields[attribute] {
+			return nil, fmt.Errorf("attribute '%s' has the name of a built-in mapping field", attribute)
+		}
+	}
+	for _, attribute := range attributes {
+		fields[attribute] = true
+	}
+	mapping := &Mapping{
+		table:    map[string]MappingTarget{},
+		epicLink: config.EpicLink,
+	}
+	for key, target := range config.Table {
+		// Keys are case-insensitive, the configuration keys are lower case anyway
+		mapping.table[strings.ToLower(key)] = MappingTarget{
+			Project: Project(target.Project),
+			Task:    Task(target.Task),
+		}
+	}
+	for i, rule := range config.Rules {
+		if rule.Field == "" {
+			return nil, fmt.Errorf("mapping rule %d has no field", i+1)
+		}
+		if !fields[rule.Field] {
+			return nil, fmt.Errorf("unknown field '%s' of mapping rule %d", rule.Field, i+1)
+		}
+		var expression, description string
+		switch {
+		case rule.Regex != "" && rule.Wildcard != "":
+			return nil, fmt.Errorf("mapping rule %d has a regex and a wildcard", i+1)
+		case rule.Regex != "":
+			expression = rule.Regex
+			description = fmt.Sprintf("rule %d: %s matches regex %s", i+1, rule.Field, rule.Regex)
+		case rule.Wildcard != "":
+			expression = wildcard(rule.Wildcard)
+			description = fmt.Sprintf("rule %d: %s matches wildcard %s", i+1, rule.Field, rule.Wildcard)
+		default:
+			return nil, fmt.Errorf("mapping rule %d has neither a regex nor a wildcard", i+1)
+		}
+		pattern, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern of mapping rule %d. %s", i+1, err.Error())
+		}
+		mapping.rules = append(mapping.rules, mappingRule{
+			field:   rule.Field,
+			pattern: pattern,
+			MappingTarget: MappingTarget{
+				Project: Project(rule.Project),
+				Task:    Task(rule.Task),
+			},
+			description: description,
+		})
+	}
+	return mapping, nil
+}
+
+// EpicLink returns the field of the epic or empty, if the epic is the parent of the issue.
+func (mapping *Mapping) EpicLink() string {
+	if mapping == nil {
+		return ""
+	}
+	return mapping.epicLink
+}
+
+// wildcard returns the anchored regular expression for a pattern with * and ?.
+func wildcard(pattern string) string {
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.ReplaceAll(expression, `\*`, ".*")
+	expression = strings.ReplaceAll(expression, `\?`, ".")
+	return "^" + expression + "$"
+}
+
+// Match returns the target for the fields or nil, if nothing matches.
+// The table is looked up by issue, epic and project in that order.
+func (mapping *Mapping) Match(fields MappingFields) *MappingMatch {
+	for _, field := range []string{MapIssue, MapEpic, MapProject} {
+		for _, value := range fields[field] {
+			if target, ok := mapping.table[strings.ToLower(value)]; ok {
+				return &MappingMatch{
+					MappingTarget: target,
+					Rule:          fmt.Sprintf("table: %s %s", field, value),
+				}
+			}
+		}
+	}
+	for _, rule := range mapping.rules {
+		for _, value := range fields[rule.field] {
+			if rule.pattern.MatchString(value) {
+				return &MappingMatch{
+					MappingTarget: rule.MappingTarget,
+					Rule:          rule.description,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Apply sets project and task of the effort to the matching target. Efforts without match are unchanged.
+func (mapping *Mapping) Apply(effort Effort, fields MappingFields) Effort {
+	if mapping == nil {
+		return effort
+	}
+	if match := mapping.Match(fields); match != nil {
+		effort.Project = match.Project
+		effort.Task = match.Task
+	}
+	return effort
+}
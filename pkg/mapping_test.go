@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"eager/internal"
+	"testing"
+)
+
+func TestMapping(t *testing.T) {
+	mapping, err := NewMapping(internal.Mapping{
+		Table: map[string]internal.MappingTarget{
+			"proj-1": {Project: "1", Task: "10"},
+			"EPIC-1": {Project: "2", Task: "20"},
+		},
+		Rules: []internal.MappingRule{
+			{Field: MapLabel, Wildcard: "billing-*", Project: "3", Task: "30"},
+			{Field: MapComponent, Regex: "^(Backend|Frontend)$", Project: "4", Task: "40"},
+			{Field: MapProject, Wildcard: "PROJ", Project: "5", Task: "50"},
+			{Field: "cost-center", Wildcard: "42", Project: "6", Task: "60"},
+		},
+	}, []string{"cost-center"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		fields MappingFields
+		task   Task
+	}{
+		{name: "table issue", fields: MappingFields{MapIssue: {"PROJ-1"}, MapEpic: {"EPIC-1"}}, task: "10"},
+		{name: "table epic", fields: MappingFields{MapIssue: {"PROJ-2"}, MapEpic: {"EPIC-1"}}, task: "20"},
+		{name: "wildcard", fields: MappingFields{MapIssue: {"PROJ-2"}, MapLabel: {"urgent", "billing-acme"}}, task: "30"},
+		{name: "regex", fields: MappingFields{MapComponent: {"Backend"}, MapProject: {"PROJ"}}, task: "40"},
+		{name: "order", fields: MappingFields{MapComponent: {"Backend API"}, MapProject: {"PROJ"}}, task: "50"},
+		{name: "attribute", fields: MappingFields{MapProject: {"OTHER"}, "cost-center": {"42"}}, task: "60"},
+		{name: "none", fields: MappingFields{MapProject: {"OTHER"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var task Task
+			if match := mapping.Match(test.fields); match != nil {
+				task = match.Task
+			}
+			if task != test.task {
+				t.Errorf("got '%s' want '%s'", task, test.task)
+			}
+		})
+	}
+}
+
+func TestNewMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       internal.MappingRule
+		attributes []string
+		valid      bool
+	}{
+		{name: "built-in", rule: internal.MappingRule{Field: MapEpic, Wildcard: "*"}, valid: true},
+		{name: "attribute", rule: internal.MappingRule{Field: "summary", Wildcard: "*"}, attributes: []string{"summary"}, valid: true},
+		{name: "unknown", rule: internal.MappingRule{Field: "summary", Wildcard: "*"}},
+		{name: "empty", rule: internal.MappingRule{Wildcard: "*"}},
+		{name: "collision", rule: internal.MappingRule{Field: MapLabel, Wildcard: "*"}, attributes: []string{MapLabel}},
+		{name: "no pattern", rule: internal.MappingRule{Field: MapLabel}},
+		{name: "invalid regex", rule: internal.MappingRule{Field: MapLabel, Regex: "("}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMapping(internal.Mapping{Rules: []internal.MappingRule{test.rule}}, test.attributes)
+			if (err == nil) != test.valid {
+				t.Errorf("got %v want valid %v", err, test.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package pkg
+
+import (
+	"eager/internal"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	MatrixRowsTask = "task"
+	MatrixRowsUser = "user"
+)
+
+type Matrix struct {
+	Header    []string
+	Rows      []MatrixRow
+	Days      []time.Time
+	DayTotals []time.Duration
+	Total     time.Duration
+}
+
+type MatrixRow struct {
+	Labels []string
+	Cells  []time.Duration
+	Total  time.Duration
+}
+
+func (ts Timesheet) Matrix(year int, month time.Month, rows string) (*Matrix, error) {
+	var header []string
+	var labels func(effort Effort) []string
+	switch rows {
+	case MatrixRowsTask:
+		header = []string{"Project", "Task"}
+		labels = func(effort Effort) []string {
+			return []string{string(effort.Project), string(effort.Task)}
+		}
+	case MatrixRowsUser:
+		header = []string{"User"}
+		labels = func(effort Effort) []string {
+			if effort.User == nil {
+				return []string{""}
+			}
+			return []string{effort.User.DisplayName}
+		}
+	default:
+		return nil, fmt.Errorf("unknown matrix rows '%s'", rows)
+	}
+
+	fromDate, toDate := GetTimeRange(year, month)
+	var days []time.Time
+	for day := fromDate; day.Before(toDate); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	matrix := &Matrix{
+		Header:    header,
+		Days:      days,
+		DayTotals: make([]time.Duration, len(days)),
+	}
+	index := map[string]*MatrixRow{}
+	for _, effort := range ts {
+		if effort.Date.Before(fromDate) || !effort.Date.Before(toDate) {
+			continue
+		}
+		rowLabels := labels(effort)
+		key := strings.Join(rowLabels, ";")
+		row := index[key]
+		if row == nil {
+			row = &MatrixRow{
+				Labels: rowLabels,
+				Cells:  make([]time.Duration, len(days)),
+			}
+			index[key] = row
+		}
+		day := effort.Date.Day() - 1
+		row.Cells[day] += effort.Duration
+		row.Total += effort.Duration
+		matrix.DayTotals[day] += effort.Duration
+		matrix.Total += effort.Duration
+	}
+	matrix.Rows = make([]MatrixRow, 0, len(index))
+	for _, row := range index {
+		matrix.Rows = append(matrix.Rows, *row)
+	}
+	sort.Slice(matrix.Rows, func(i, j int) bool {
+		return strings.Join(matrix.Rows[i].Labels, ";") < strings.Join(matrix.Rows[j].Labels, ";")
+	})
+	return matrix, nil
+}
+
+func (matrix *Matrix) records(opts *internal.DurationOptions) [][]string {
+	cell := func(duration time.Duration) string {
+		if duration == 0 && !opts.Empty {
+			return ""
+		}
+		return FormatDuration(duration, opts)
+	}
+
+	records := make([][]string, 0, len(matrix.Rows)+2)
+	header := append([]string{}, matrix.Header...)
+	for _, day := range matrix.Days {
+		header = append(header, strconv.Itoa(day.Day()))
+	}
+	records = append(records, append(header, "Total"))
+	for _, row := range matrix.Rows {
+		record := append([]string{}, row.Labels...)
+		for _, duration := range row.Cells {
+			record = append(record, cell(duration))
+		}
+		records = append(records, append(record, FormatDuration(row.Total, opts)))
+	}
+	footer := make([]string, len(matrix.Header))
+	footer[0] = "Total"
+	for _, duration := range matrix.DayTotals {
+		footer = append(footer, cell(duration))
+	}
+	return append(records, append(footer, FormatDuration(matrix.Total, opts)))
+}
+
+func (matrix *Matrix) WriteCsv(writer io.Writer, opts *internal.DurationOptions) error {
+	csvw := csv.NewWriter(writer)
+	csvw.Comma = ';'
+	return csvw.WriteAll(matrix.records(opts))
+}
+
+func (matrix *Matrix) WriteTable(writer io.Writer, opts *internal.DurationOptions) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 1, ' ', tabwriter.AlignRight)
+	for _, record := range matrix.records(opts) {
+		_, err := fmt.Fprintln(tw, strings.Join(record, "\t")+"\t")
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+var matrixHtml = template.Must(template.New("matrix").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Timesheet</title>
+<style>
+table { border-collapse: collapse; font-family: sans-serif; font-size: small; }
+th, td { border: 1px solid #999; padding: 2px 4px; text-align: right; }
+thead th, tfoot th { background: #eee; }
+</style>
+</head>
+<body>
+<table>
+<thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Body}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+<tfoot><tr>{{range .Footer}}<th>{{.}}</th>{{end}}</tr></tfoot>
+</table>
+</body>
+</html>
+`))
+
+func (matrix *Matrix) WriteHtml(writer io.Writer, opts *internal.DurationOptions) error {
+	records := matrix.records(opts)
+	return matrixHtml.Execute(writer, struct {
+		Header []string
+		Body   [][]string
+		Footer []string
+	}{
+		Header: records[0],
+		Body:   records[1 : len(records)-1],
+		Footer: records[len(records)-1],
+	})
+}
@@ -0,0 +1,100 @@
+package pkg
+
+import (
+	"bytes"
+	"eager/internal"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatrix(t *testing.T) {
+	day := func(day int) time.Time { return time.Date(2022, time.February, day, 0, 0, 0, 0, time.UTC) }
+	jane := &User{DisplayName: "Jane Doe"}
+	john := &User{DisplayName: "John Doe"}
+	timesheet := Timesheet{
+		{User: john, Project: "PROJ", Task: "PROJ-2", Date: day(1), Duration: time.Hour},
+		{User: jane, Project: "PROJ", Task: "PROJ-1", Date: day(1), Duration: 30 * time.Minute},
+		{User: jane, Project: "PROJ", Task: "PROJ-1", Date: day(28), Duration: 2 * time.Hour},
+		{User: jane, Project: "PROJ", Task: "PROJ-1", Date: time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC), Duration: time.Hour},
+	}
+	tests := []struct {
+		rows      string
+		labels    [][]string
+		totals    []time.Duration
+		dayTotals map[int]time.Duration
+	}{
+		{
+			rows:      MatrixRowsTask,
+			labels:    [][]string{{"PROJ", "PROJ-1"}, {"PROJ", "PROJ-2"}},
+			totals:    []time.Duration{150 * time.Minute, time.Hour},
+			dayTotals: map[int]time.Duration{1: 90 * time.Minute, 28: 2 * time.Hour},
+		},
+		{
+			rows:      MatrixRowsUser,
+			labels:    [][]string{{"Jane Doe"}, {"John Doe"}},
+			totals:    []time.Duration{150 * time.Minute, time.Hour},
+			dayTotals: map[int]time.Duration{1: 90 * time.Minute, 28: 2 * time.Hour},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rows, func(t *testing.T) {
+			matrix, err := timesheet.Matrix(2022, time.February, tt.rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matrix.Days) != 28 {
+				t.Errorf("got %d days want 28", len(matrix.Days))
+			}
+			var labels [][]string
+			var totals []time.Duration
+			for _, row := range matrix.Rows {
+				labels = append(labels, row.Labels)
+				totals = append(totals, row.Total)
+			}
+			if !reflect.DeepEqual(labels, tt.labels) {
+				t.Errorf("got %v want %v", labels, tt.labels)
+			}
+			if !reflect.DeepEqual(totals, tt.totals) {
+				t.Errorf("got %v want %v", totals, tt.totals)
+			}
+			for i, total := range matrix.DayTotals {
+				if total != tt.dayTotals[i+1] {
+					t.Errorf("got %s on day %d want %s", total, i+1, tt.dayTotals[i+1])
+				}
+			}
+			if matrix.Total != 210*time.Minute {
+				t.Errorf("got %s want %s", matrix.Total, 210*time.Minute)
+			}
+		})
+	}
+
+	if _, err := timesheet.Matrix(2022, time.February, "project"); err == nil {
+		t.Errorf("got no error for unknown rows")
+	}
+}
+
+func TestMatrixCsv(t *testing.T) {
+	timesheet := Timesheet{{Project: "PROJ", Task: "PROJ-1", Date: time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC), Duration: 90 * time.Minute}}
+	matrix, err := timesheet.Matrix(2022, time.February, MatrixRowsTask)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	err = matrix.WriteCsv(&buffer, &internal.DurationOptions{Decimal: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	want := []string{
+		"Project;Task;1;2;3",
+		"PROJ;PROJ-1;;1.50;",
+		"Total;;;1.50;",
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("got %s want prefix %s", lines[i], prefix)
+		}
+	}
+}
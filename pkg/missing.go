@@ -0,0 +1,92 @@
+package pkg
+
+import (
+	"eager/internal"
+	"encoding/csv"
+	"io"
+	"log"
+	"time"
+)
+
+// Missing is a working day of a user without enough effort.
+type Missing struct {
+	User     *User
+	Date     time.Time
+	Duration time.Duration
+}
+
+// Missing returns every working day of the given month before the given date, where the effort of a user is below the threshold.
+// Without threshold only days without any effort are returned.
+// Working days are the days with a target effort inside the working time or monday to friday, if there is no working time.
+// Days with an absence inside the optional calendar are no working days.
+func (ts Timesheet) Missing(year int, month time.Month, until time.Time, users []*User, threshold time.Duration, workingTimes WorkingTimes, calendar Calendar) []Missing {
+	users = ts.withUsers(users).Users()
+	if len(users) == 0 {
+		users = []*User{nil}
+	}
+	actual := map[string]map[time.Time]time.Duration{}
+	for _, effort := range ts.summarize() {
+		name := ""
+		if effort.User != nil {
+			name = effort.User.DisplayName
+		}
+		if actual[name] == nil {
+			actual[name] = map[time.Time]time.Duration{}
+		}
+		actual[name][effort.Date] = effort.Duration
+	}
+
+	fromDate, toDate := GetTimeRange(year, month)
+	if until.Before(toDate) {
+		toDate = until
+	}
+	var result []Missing
+	for _, user := range users {
+		name := ""
+		if user != nil {
+			name = user.DisplayName
+		}
+		workingTime := workingTimes.Get(user)
+		for date := fromDate; date.Before(toDate); date = date.AddDate(0, 0, 1) {
+			if workingTime != nil && workingTime.Target(date) == 0 {
+				continue
+			}
+			if workingTime == nil && (date.Weekday() == time.Saturday || date.Weekday() == time.Sunday) {
+				continue
+			}
+			if calendar != nil && calendar.Absence(user, date) != nil {
+				continue
+			}
+			duration := actual[name][date]
+			if duration == 0 || duration < threshold {
+				result = append(result, Missing{
+					User:     user,
+					Date:     date,
+					Duration: duration,
+				})
+			}
+		}
+	}
+	return result
+}
+
+func WriteMissing(writer io.Writer, missing []Missing, user bool, opts *internal.DurationOptions) {
+	csvw := csv.NewWriter(writer)
+	csvw.Comma = ';'
+	for _, m := range missing {
+		var result []string
+		if user {
+			name := ""
+			if m.User != nil {
+				name = m.User.DisplayName
+			}
+			result = append(result, name)
+		}
+		result = append(result, m.Date.Format(IsoYearMonthDay), FormatDuration(m.Duration, opts))
+		err := csvw.Write(result)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	csvw.Flush()
+}
@@ -0,0 +1,56 @@
+package pkg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMissing(t *testing.T) {
+	day := func(day int) time.Time { return time.Date(2022, time.August, day, 0, 0, 0, 0, time.UTC) }
+	jane := &User{DisplayName: "Jane Doe"}
+	john := &User{DisplayName: "John Doe"}
+	// Monday to Friday, 1st to 5th of August
+	timesheet := Timesheet{
+		{User: jane, Date: day(1), Duration: 8 * time.Hour},
+		{User: jane, Date: day(2), Duration: 2 * time.Hour},
+		{User: jane, Date: day(2), Duration: time.Hour},
+		{User: jane, Date: day(4), Duration: 6 * time.Hour},
+	}
+	workingTimes := WorkingTimes{"John Doe": {Days: map[time.Weekday]time.Duration{time.Friday: 4 * time.Hour}}}
+	tests := []struct {
+		name      string
+		users     []*User
+		threshold time.Duration
+		calendar  Calendar
+		want      []Missing
+	}{
+		{
+			name: "days without effort",
+			want: []Missing{{User: jane, Date: day(3)}, {User: jane, Date: day(5)}},
+		},
+		{
+			name:      "threshold",
+			threshold: 4 * time.Hour,
+			want:      []Missing{{User: jane, Date: day(2), Duration: 3 * time.Hour}, {User: jane, Date: day(3)}, {User: jane, Date: day(5)}},
+		},
+		{
+			name:     "absence",
+			calendar: absenceCalendar{"2022-08-03": {Kind: AbsenceSick}},
+			want:     []Missing{{User: jane, Date: day(5)}},
+		},
+		{
+			name:  "users without effort and their working days",
+			users: []*User{john},
+			want:  []Missing{{User: jane, Date: day(3)}, {User: jane, Date: day(5)}, {User: john, Date: day(5)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timesheet.Missing(2022, time.August, day(6), tt.users, tt.threshold, workingTimes, tt.calendar)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	KindText    = "text"
+	KindDesktop = "desktop"
+	KindCommand = "command"
+)
+
+// Notifier delivers a message to the user.
+type Notifier interface {
+	Notify(title string, message string) error
+}
+
+// New returns the notifier of the given kind.
+// The command is only used for the command notifier.
+func New(kind string, writer io.Writer, command []string) (Notifier, error) {
+	switch kind {
+	case KindText:
+		return &Text{Writer: writer}, nil
+	case KindDesktop:
+		return Desktop()
+	case KindCommand:
+		if len(command) == 0 {
+			return nil, fmt.Errorf("there is no notification command inside your configuration")
+		}
+		return &Command{Name: command[0], Args: command[1:]}, nil
+	}
+	return nil, fmt.Errorf("unknown notifier '%s'", kind)
+}
+
+// Text writes the message to the writer.
+type Text struct {
+	Writer io.Writer
+}
+
+func (n *Text) Notify(title string, message string) error {
+	_, err := fmt.Fprintln(n.Writer, message)
+	return err
+}
+
+// Command executes a program with title and message as last arguments.
+type Command struct {
+	Name string
+	Args []string
+}
+
+func (n *Command) Notify(title string, message string) error {
+	args := append(append([]string{}, n.Args...), title, message)
+	output, err := exec.Command(n.Name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("cannot notify with %s. %s %s", n.Name, err.Error(), output)
+	}
+	return nil
+}
+
+// Desktop returns the notifier of the desktop environment.
+func Desktop() (Notifier, error) {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return &Command{Name: "notify-send"}, nil
+	case "darwin":
+		return &script{name: "osascript", args: []string{"-e"}, format: `display notification "%s" with title "%s"`, escape: appleScript}, nil
+	case "windows":
+		return &script{name: "powershell", args: []string{"-NoProfile", "-Command"}, format: `[reflection.assembly]::loadwithpartialname('System.Windows.Forms') | Out-Null; ` +
+			`$n = New-Object System.Windows.Forms.NotifyIcon; $n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; ` +
+			`$n.ShowBalloonTip(10000, '%[2]s', '%[1]s', 'Info'); Start-Sleep -Seconds 1`, escape: powerShell}, nil
+	}
+	return nil, fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+}
+
+// appleScript escapes the value for a string literal of AppleScript, that is enclosed by double quotes.
+func appleScript(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// powerShell escapes the value for a string literal of PowerShell, that is enclosed by single quotes.
+func powerShell(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// script executes a program with a script containing message and title.
+type script struct {
+	name   string
+	args   []string
+	format string
+	// escape quotes message and title for the script
+	escape func(string) string
+}
+
+func (n *script) Notify(title string, message string) error {
+	if n.escape != nil {
+		title, message = n.escape(title), n.escape(message)
+	}
+	args := append(append([]string{}, n.args...), fmt.Sprintf(n.format, message, title))
+	output, err := exec.Command(n.name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("cannot notify with %s. %s %s", n.name, err.Error(), output)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		kind    string
+		command []string
+		err     bool
+	}{
+		{kind: KindText},
+		{kind: KindCommand, command: []string{"mail-team", "--subject"}},
+		{kind: KindCommand, err: true},
+		{kind: "mail", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			_, err := New(tt.kind, &bytes.Buffer{}, tt.command)
+			if (err != nil) != tt.err {
+				t.Errorf("got error %v", err)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	var buffer bytes.Buffer
+	err := (&Text{Writer: &buffer}).Notify("Missing effort on 2 days", "2022-08-01\n2022-08-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buffer.String(), "2022-08-01\n2022-08-02\n"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a shell")
+	}
+	file := filepath.Join(t.TempDir(), "notification")
+	notifier := &Command{Name: "sh", Args: []string{"-c", `printf '%s|%s' "$1" "$2" > "$0"`, file}}
+	err := notifier.Notify("Missing effort", `2022-08-01 "today"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `Missing effort|2022-08-01 "today"`; got != want {
+		t.Errorf("got %s want %s", got, want)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	if got, want := appleScript(`C:\temp "quoted"`), `C:\\temp \"quoted\"`; got != want {
+		t.Errorf("got %s want %s", got, want)
+	}
+	if got, want := powerShell(`it's`), `it''s`; got != want {
+		t.Errorf("got %s want %s", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"fmt"
+	"time"
+)
+
+// Provider reads and writes the worklog of the current user inside a store.
+type Provider interface {
+	// Timesheet returns the effort of the month.
+	Timesheet(year int, month time.Month) (Timesheet, error)
+	// Add books the effort and returns the id of the new worklog, which is empty for a dry run.
+	// Without start, the effort ends now on the current day or starts at midnight on another day.
+	Add(effort Effort) (string, error)
+	// Remove deletes the effort by its id.
+	Remove(effort Effort) error
+}
+
+// Batcher groups the changes of a provider in batches of the journal.
+type Batcher interface {
+	// Batch returns the provider, that records every change inside a new batch.
+	Batch() Provider
+}
+
+// Batched returns the provider with a new batch of changes, if it is a Batcher.
+// Long-running processes use it for every action, so that an undo reverses only the last action.
+func Batched(provider Provider) Provider {
+	if batcher, ok := provider.(Batcher); ok {
+		return batcher.Batch()
+	}
+	return provider
+}
+
+// BulkProvider reads the worklog of other users, too.
+type BulkProvider interface {
+	Provider
+	// BulkTimesheet returns the effort of the users for the month.
+	BulkTimesheet(year int, month time.Month, users []*User) (Timesheet, error)
+}
+
+// TaskValidator checks the task of an effort before it is booked.
+type TaskValidator interface {
+	// ValidateTask returns an error, if the task does not exist inside the store.
+	ValidateTask(task Task) error
+}
+
+// Transfer copies the selected efforts of the source to the target with another task or on another day.
+// A move removes every effort from the source after it is added to the target. The efforts keep their duration and description.
+// An empty task keeps the task, a zero date keeps the day of every effort. On another day, the efforts keep their time of day.
+func Transfer(source, target Provider, selection Selection, task Task, date time.Time, move bool, confirm ConfirmFunc) error {
+	if !selection.Bounded() {
+		return fmt.Errorf("the selection needs a first and last day")
+	}
+	var timesheet Timesheet
+	for month := time.Date(selection.From.Year(), selection.From.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(selection.To); month = month.AddDate(0, 1, 0) {
+		efforts, err := source.Timesheet(month.Year(), month.Month())
+		if err != nil {
+			return err
+		}
+		timesheet = append(timesheet, efforts.Select(selection)...)
+	}
+	if len(timesheet) == 0 {
+		return fmt.Errorf("found no effort")
+	}
+
+	operation := "Copy these efforts"
+	if move {
+		operation = "Move these efforts"
+	}
+	if task != "" {
+		operation += fmt.Sprintf(" to %s", task)
+	}
+	if !date.IsZero() {
+		operation += fmt.Sprintf(" on %s", date.Format(IsoYearMonthDay))
+	}
+	ok, err := confirm(operation, timesheet.Stringers())
+	if err != nil || !ok {
+		return err
+	}
+
+	for _, effort := range timesheet {
+		transferred := effort
+		transferred.Id = ""
+		if task != "" {
+			transferred.Task = task
+			transferred.Project = ""
+		}
+		if !date.IsZero() {
+			transferred.Date = date
+			// The effort starts at the same time of day on the new date
+			if start := effort.Start; !start.IsZero() {
+				transferred.Start = time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+			}
+		}
+		_, err = target.Add(transferred)
+		if err != nil {
+			return err
+		}
+		if move {
+			err = source.Remove(effort)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
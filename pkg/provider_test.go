@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTransfer(t *testing.T) {
+	day := func(month time.Month, day int) time.Time { return time.Date(2022, month, day, 0, 0, 0, 0, time.UTC) }
+	start := time.Date(2022, 8, 31, 9, 0, 0, 0, time.UTC)
+	timesheet := Timesheet{
+		{Id: "1", Project: "PROJ", Task: "PROJ-1", Description: "Fix", Date: day(8, 31), Start: start, Duration: time.Hour},
+		{Id: "2", Project: "PROJ", Task: "PROJ-1", Description: "Test", Date: day(9, 1), Duration: 30 * time.Minute},
+		{Id: "3", Project: "PROJ", Task: "PROJ-2", Description: "Other", Date: day(9, 1), Duration: time.Hour},
+	}
+	selection := Selection{From: day(8, 31), To: day(9, 1), Task: "PROJ-1"}
+	var operation string
+	confirm := func(text string, items []fmt.Stringer) (bool, error) {
+		operation = text
+		return true, nil
+	}
+
+	t.Run("move task", func(t *testing.T) {
+		source := &fakeProvider{Efforts: timesheet}
+		err := Transfer(source, source, selection, "PROJ-3", time.Time{}, true, confirm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := Timesheet{
+			{Task: "PROJ-3", Description: "Fix", Date: day(8, 31), Start: start, Duration: time.Hour},
+			{Task: "PROJ-3", Description: "Test", Date: day(9, 1), Duration: 30 * time.Minute},
+		}
+		var removed []string
+		for _, effort := range source.Removed {
+			removed = append(removed, effort.Id)
+		}
+		if !reflect.DeepEqual(source.Added, want) || !reflect.DeepEqual(removed, []string{"1", "2"}) {
+			t.Errorf("got %v, %v want %v", source.Added, source.Removed, want)
+		}
+		if operation != "Move these efforts to PROJ-3" {
+			t.Errorf("got %s", operation)
+		}
+	})
+
+	t.Run("copy day", func(t *testing.T) {
+		source, target := &fakeProvider{Efforts: timesheet}, &fakeProvider{}
+		err := Transfer(source, target, selection, "", day(9, 5), false, confirm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := Timesheet{
+			{Project: "PROJ", Task: "PROJ-1", Description: "Fix", Date: day(9, 5), Start: time.Date(2022, 9, 5, 9, 0, 0, 0, time.UTC), Duration: time.Hour},
+			{Project: "PROJ", Task: "PROJ-1", Description: "Test", Date: day(9, 5), Duration: 30 * time.Minute},
+		}
+		if !reflect.DeepEqual(target.Added, want) || len(source.Removed) > 0 {
+			t.Errorf("got %v, %v want %v", target.Added, source.Removed, want)
+		}
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		source := &fakeProvider{Efforts: timesheet}
+		decline := func(string, []fmt.Stringer) (bool, error) { return false, nil }
+		err := Transfer(source, source, selection, "PROJ-3", time.Time{}, true, decline)
+		if err != nil || len(source.Added) > 0 || len(source.Removed) > 0 {
+			t.Errorf("got %v, %v, %v", err, source.Added, source.Removed)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"eager/internal"
+	"fmt"
+	"time"
+)
+
+// Recurrence is an effort, that is booked on every matching day.
+type Recurrence struct {
+	Task        Task
+	Duration    time.Duration
+	Description Description
+	Weekdays    map[time.Weekday]bool
+	// Since and Until are the first and the last day, zero for no bound
+	Since time.Time
+	Until time.Time
+}
+
+func NewRecurrences(models []internal.Recurrence) ([]Recurrence, error) {
+	result := make([]Recurrence, 0, len(models))
+	for _, model := range models {
+		recurrence, err := newRecurrence(model)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurring effort for '%s'. %s", model.Task, err.Error())
+		}
+		result = append(result, recurrence)
+	}
+	return result, nil
+}
+
+func newRecurrence(model internal.Recurrence) (Recurrence, error) {
+	if model.Task == "" {
+		return Recurrence{}, fmt.Errorf("the task is missing")
+	}
+	if model.Duration <= 0 {
+		return Recurrence{}, fmt.Errorf("the duration is missing")
+	}
+	recurrence := Recurrence{
+		Task:        Task(model.Task),
+		Duration:    model.Duration,
+		Description: Description(model.Description),
+		Weekdays:    map[time.Weekday]bool{},
+	}
+	names := model.Weekdays
+	if len(names) == 0 {
+		names = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+	}
+	for _, name := range names {
+		day, err := ParseWeekday(name)
+		if err != nil {
+			return Recurrence{}, err
+		}
+		recurrence.Weekdays[day] = true
+	}
+	var err error
+	if model.Since != "" {
+		recurrence.Since, err = time.Parse(IsoYearMonthDay, model.Since)
+		if err != nil {
+			return Recurrence{}, fmt.Errorf("since must be formatted as %s", IsoYearMonthDay)
+		}
+	}
+	if model.Until != "" {
+		recurrence.Until, err = time.Parse(IsoYearMonthDay, model.Until)
+		if err != nil {
+			return Recurrence{}, fmt.Errorf("until must be formatted as %s", IsoYearMonthDay)
+		}
+	}
+	return recurrence, nil
+}
+
+// Occurs tells, if the recurrence is due on the day.
+func (recurrence Recurrence) Occurs(date time.Time) bool {
+	if !recurrence.Since.IsZero() && date.Before(recurrence.Since) {
+		return false
+	}
+	if !recurrence.Until.IsZero() && date.After(recurrence.Until) {
+		return false
+	}
+	return recurrence.Weekdays[date.Weekday()]
+}
+
+// Occurrences returns the efforts of every recurrence between both days, including the last day.
+// Days with an absence of the current user inside the optional calendar are skipped.
+func Occurrences(recurrences []Recurrence, fromDate, toDate time.Time, calendar Calendar) Timesheet {
+	var result Timesheet
+	for date := fromDate; !date.After(toDate); date = date.AddDate(0, 0, 1) {
+		if calendar != nil && calendar.Absence(&User{}, date) != nil {
+			continue
+		}
+		for _, recurrence := range recurrences {
+			if recurrence.Occurs(date) {
+				result = append(result, Effort{
+					Task:        recurrence.Task,
+					Description: recurrence.Description,
+					Date:        date,
+					Duration:    recurrence.Duration,
+				})
+			}
+		}
+	}
+	return result
+}
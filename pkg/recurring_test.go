@@ -0,0 +1,64 @@
+package pkg
+
+import (
+	"eager/internal"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type absenceCalendar map[string]*Absence
+
+func (calendar absenceCalendar) Absence(_ *User, date time.Time) *Absence {
+	return calendar[date.Format(IsoYearMonthDay)]
+}
+
+func TestOccurrences(t *testing.T) {
+	recurrences, err := NewRecurrences([]internal.Recurrence{
+		{Task: "PROJ-1", Duration: 15 * time.Minute, Description: "Standup"},
+		{Task: "PROJ-2", Duration: time.Hour, Description: "Jour fixe", Weekdays: []string{"tuesday"}, Until: "2022-08-02"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	standup := func(day int) Effort {
+		return Effort{Task: "PROJ-1", Description: "Standup", Date: time.Date(2022, 8, day, 0, 0, 0, 0, time.UTC), Duration: 15 * time.Minute}
+	}
+	jourFixe := Effort{Task: "PROJ-2", Description: "Jour fixe", Date: time.Date(2022, 8, 2, 0, 0, 0, 0, time.UTC), Duration: time.Hour}
+	tests := []struct {
+		name     string
+		calendar Calendar
+		want     Timesheet
+	}{
+		{"without calendar", nil, Timesheet{standup(1), standup(2), jourFixe, standup(3), standup(4), standup(5), standup(8), standup(9)}},
+		{"with absence", absenceCalendar{"2022-08-02": {Kind: AbsenceVacation}}, Timesheet{standup(1), standup(3), standup(4), standup(5), standup(8), standup(9)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Occurrences(recurrences, time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 8, 9, 0, 0, 0, 0, time.UTC), tt.calendar)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRecurrences(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence internal.Recurrence
+	}{
+		{"missing task", internal.Recurrence{Duration: time.Hour}},
+		{"missing duration", internal.Recurrence{Task: "PROJ-1"}},
+		{"unknown weekday", internal.Recurrence{Task: "PROJ-1", Duration: time.Hour, Weekdays: []string{"someday"}}},
+		{"invalid since", internal.Recurrence{Task: "PROJ-1", Duration: time.Hour, Since: "01.08.2022"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRecurrences([]internal.Recurrence{tt.recurrence})
+			if err == nil {
+				t.Errorf("got no error")
+			}
+		})
+	}
+}
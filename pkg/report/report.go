@@ -0,0 +1,170 @@
+package report
+
+import (
+	"eager/internal"
+	"eager/pkg"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+const (
+	TemplateMonthly  = "monthly"
+	TemplateActivity = "activity"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Report is the data every template is executed with.
+type Report struct {
+	Year      int
+	Month     time.Month
+	Timesheet pkg.Timesheet
+}
+
+type UserTimesheet struct {
+	User      *pkg.User
+	Timesheet pkg.Timesheet
+}
+
+type TaskTimesheet struct {
+	Project   pkg.Project
+	Task      pkg.Task
+	Timesheet pkg.Timesheet
+}
+
+type executor interface {
+	Execute(writer io.Writer, data interface{}) error
+}
+
+// Write renders the timesheet with the given template.
+// The template is either the name of a built-in template or a file.
+// Files ending with .html or .htm are rendered with html/template, every other file with text/template.
+func Write(writer io.Writer, template string, year int, month time.Month, timesheet pkg.Timesheet, opts *internal.DurationOptions) error {
+	name := template
+	var data []byte
+	var err error
+	switch template {
+	case TemplateMonthly, TemplateActivity:
+		name = template + ".html"
+		data, err = templates.ReadFile("templates/" + name)
+	default:
+		data, err = os.ReadFile(template)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read template %s", template)
+	}
+
+	functions := funcMap(year, month, opts)
+	var tmpl executor
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".html", ".htm":
+		tmpl, err = htmltemplate.New(filepath.Base(name)).Funcs(functions).Parse(string(data))
+	default:
+		tmpl, err = texttemplate.New(filepath.Base(name)).Funcs(functions).Parse(string(data))
+	}
+	if err != nil {
+		return fmt.Errorf("cannot parse template %s. %s", template, err.Error())
+	}
+	return tmpl.Execute(writer, Report{
+		Year:      year,
+		Month:     month,
+		Timesheet: timesheet,
+	})
+}
+
+func funcMap(year int, month time.Month, opts *internal.DurationOptions) map[string]interface{} {
+	return map[string]interface{}{
+		// total sums up the duration of the timesheet
+		"total": func(timesheet pkg.Timesheet) time.Duration {
+			return timesheet.Total()
+		},
+		// hours formats the duration as decimal hours
+		"hours": func(duration time.Duration) string {
+			return fmt.Sprintf("%.02f", duration.Hours())
+		},
+		// duration formats the duration according to the duration options
+		"duration": func(duration time.Duration) string {
+			return pkg.FormatDuration(duration, opts)
+		},
+		// days returns every day of the month, including the days without effort
+		"days": func(timesheet pkg.Timesheet) []pkg.Day {
+			return timesheet.Days(year, month)
+		},
+		"users": users,
+		"tasks": tasks,
+		"weekend": func(date time.Time) bool {
+			return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+		},
+		"date": func(layout string, date time.Time) string {
+			return date.Format(layout)
+		},
+	}
+}
+
+// users splits the timesheet per user, sorted by name. Efforts without user come first.
+func users(timesheet pkg.Timesheet) []UserTimesheet {
+	name := func(user *pkg.User) string {
+		if user == nil {
+			return ""
+		}
+		return user.DisplayName
+	}
+	index := map[string]*UserTimesheet{}
+	for _, effort := range timesheet {
+		entry := index[name(effort.User)]
+		if entry == nil {
+			entry = &UserTimesheet{User: effort.User}
+			index[name(effort.User)] = entry
+		}
+		entry.Timesheet = append(entry.Timesheet, effort)
+	}
+	result := make([]UserTimesheet, 0, len(index))
+	for _, entry := range index {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return name(result[i].User) < name(result[j].User)
+	})
+	return result
+}
+
+// tasks splits the timesheet per project and task, sorted by project and task
+func tasks(timesheet pkg.Timesheet) []TaskTimesheet {
+	type Key struct {
+		project pkg.Project
+		task    pkg.Task
+	}
+	index := map[Key]*TaskTimesheet{}
+	for _, effort := range timesheet {
+		key := Key{effort.Project, effort.Task}
+		entry := index[key]
+		if entry == nil {
+			entry = &TaskTimesheet{Project: effort.Project, Task: effort.Task}
+			index[key] = entry
+		}
+		entry.Timesheet = append(entry.Timesheet, effort)
+	}
+	result := make([]TaskTimesheet, 0, len(index))
+	for _, entry := range index {
+		sort.SliceStable(entry.Timesheet, func(i, j int) bool {
+			return entry.Timesheet[i].Date.Before(entry.Timesheet[j].Date)
+		})
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Project != result[j].Project {
+			return result[i].Project < result[j].Project
+		}
+		return result[i].Task < result[j].Task
+	})
+	return result
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bytes"
+	"eager/internal"
+	"eager/pkg"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(day int) time.Time {
+	return time.Date(2022, time.August, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestUsers(t *testing.T) {
+	jane := &pkg.User{DisplayName: "Jane Doe"}
+	john := &pkg.User{DisplayName: "John Doe"}
+	timesheet := pkg.Timesheet{
+		{User: john, Date: day(1)},
+		{User: nil, Date: day(1)},
+		{User: jane, Date: day(2)},
+		{User: john, Date: day(3)},
+	}
+	var got []string
+	for _, user := range users(timesheet) {
+		name := "-"
+		if user.User != nil {
+			name = user.User.DisplayName
+		}
+		got = append(got, fmt.Sprintf("%s:%d", name, len(user.Timesheet)))
+	}
+	want := []string{"-:1", "Jane Doe:1", "John Doe:2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestTasks(t *testing.T) {
+	timesheet := pkg.Timesheet{
+		{Project: "B", Task: "B-1", Date: day(2)},
+		{Project: "A", Task: "A-2", Date: day(3)},
+		{Project: "A", Task: "A-2", Date: day(1)},
+	}
+	result := tasks(timesheet)
+	if len(result) != 2 || result[0].Task != "A-2" || result[1].Task != "B-1" {
+		t.Fatalf("got %v want A-2 and B-1", result)
+	}
+	if !result[0].Timesheet[0].Date.Equal(day(1)) {
+		t.Errorf("got %v want efforts sorted by date", result[0].Timesheet)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	jane := &pkg.User{DisplayName: "Jane Doe"}
+	timesheet := pkg.Timesheet{
+		{User: jane, Project: "PROJ", Task: "PROJ-1", Description: "Fix <login>", Date: day(1), Duration: 90 * time.Minute},
+		{User: jane, Project: "PROJ", Task: "PROJ-2", Date: day(2), Duration: time.Hour},
+	}
+	file := filepath.Join(t.TempDir(), "report.txt")
+	err := os.WriteFile(file, []byte(`{{range users .Timesheet}}{{.User.DisplayName}} {{duration (total .Timesheet)}}{{end}}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		template string
+		contains []string
+	}{
+		{TemplateMonthly, []string{"Jane Doe", "2022-08-31", "<td>PROJ-1</td>", "2.50"}},
+		{TemplateActivity, []string{"PROJ PROJ-1", "Fix &lt;login&gt;", "1.50", "2.50"}},
+		{file, []string{"Jane Doe 2h30m0s"}},
+	}
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.template), func(t *testing.T) {
+			var buffer bytes.Buffer
+			err := Write(&buffer, tt.template, 2022, time.August, timesheet, &internal.DurationOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, text := range tt.contains {
+				if !strings.Contains(buffer.String(), text) {
+					t.Errorf("got %s want %s", buffer.String(), text)
+				}
+			}
+		})
+	}
+
+	if err := Write(&bytes.Buffer{}, filepath.Join(t.TempDir(), "missing.txt"), 2022, time.August, timesheet, &internal.DurationOptions{}); err == nil {
+		t.Errorf("got no error for a missing template")
+	}
+}
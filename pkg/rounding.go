@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"eager/internal"
+	"fmt"
+	"time"
+)
+
+const (
+	RoundUp      = "up"
+	RoundDown    = "down"
+	RoundNearest = "nearest"
+	RoundEntry   = "entry"
+	RoundDay     = "day"
+)
+
+func ValidateRounding(rounding internal.Rounding) error {
+	if rounding.Unit < 0 {
+		return fmt.Errorf("the rounding unit must not be negative")
+	}
+	switch rounding.Mode {
+	case RoundUp, RoundDown, RoundNearest:
+	default:
+		return fmt.Errorf("unknown rounding mode '%s'", rounding.Mode)
+	}
+	switch rounding.Scope {
+	case RoundEntry, RoundDay:
+	default:
+		return fmt.Errorf("unknown rounding scope '%s'", rounding.Scope)
+	}
+	return nil
+}
+
+// Round rounds the duration to a multiple of the rounding unit. Without unit the duration is returned unchanged.
+func Round(duration time.Duration, rounding internal.Rounding) time.Duration {
+	if rounding.Unit <= 0 {
+		return duration
+	}
+	switch rounding.Mode {
+	case RoundUp:
+		if rest := duration % rounding.Unit; rest > 0 {
+			return duration - rest + rounding.Unit
+		}
+		return duration
+	case RoundDown:
+		return duration.Truncate(rounding.Unit)
+	}
+	return duration.Round(rounding.Unit)
+}
+
+// RoundBooking rounds the duration of a new worklog. A worklog needs a positive duration, so a duration rounded to zero is an error.
+func RoundBooking(duration time.Duration, rounding internal.Rounding) (time.Duration, error) {
+	rounded := Round(duration, rounding)
+	if rounded <= 0 {
+		if rounded == duration {
+			return 0, fmt.Errorf("the duration %s must be positive", duration)
+		}
+		return 0, fmt.Errorf("the duration %s is rounded to %s", duration, rounded)
+	}
+	return rounded, nil
+}
+
+// Round returns a copy of the timesheet with rounded durations.
+// Rounding per entry keeps every effort, rounding per day summarizes the effort per user and day first.
+func (ts Timesheet) Round(rounding internal.Rounding) Timesheet {
+	timesheet := append(Timesheet{}, ts...)
+	if rounding.Scope == RoundDay {
+		timesheet = timesheet.summarize()
+	}
+	for i := range timesheet {
+		timesheet[i].Duration = Round(timesheet[i].Duration, rounding)
+	}
+	return timesheet
+}
+
+// rounded returns the function to get the rounded duration of an effort of the printed timesheet.
+// Summarized efforts get the sum of the rounded efforts of that day.
+func (ts Timesheet) rounded(rounding internal.Rounding, summarize bool) func(effort Effort) time.Duration {
+	if !summarize {
+		return func(effort Effort) time.Duration {
+			return Round(effort.Duration, rounding)
+		}
+	}
+	type Key struct {
+		user string
+		time.Time
+	}
+	sum := map[Key]time.Duration{}
+	for _, effort := range ts.Round(rounding) {
+		name := ""
+		if effort.User != nil {
+			name = effort.User.DisplayName
+		}
+		sum[Key{name, effort.Date}] += effort.Duration
+	}
+	return func(effort Effort) time.Duration {
+		name := ""
+		if effort.User != nil {
+			name = effort.User.DisplayName
+		}
+		return sum[Key{name, effort.Date}]
+	}
+}
@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"eager/internal"
+	"testing"
+	"time"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		rounding internal.Rounding
+		want     time.Duration
+	}{
+		{duration: 20 * time.Minute, rounding: internal.Rounding{Unit: 15 * time.Minute, Mode: RoundUp}, want: 30 * time.Minute},
+		{duration: 30 * time.Minute, rounding: internal.Rounding{Unit: 15 * time.Minute, Mode: RoundUp}, want: 30 * time.Minute},
+		{duration: 29 * time.Minute, rounding: internal.Rounding{Unit: 15 * time.Minute, Mode: RoundDown}, want: 15 * time.Minute},
+		{duration: 22 * time.Minute, rounding: internal.Rounding{Unit: 15 * time.Minute, Mode: RoundNearest}, want: 15 * time.Minute},
+		{duration: 9 * time.Minute, rounding: internal.Rounding{Unit: 6 * time.Minute, Mode: RoundNearest}, want: 12 * time.Minute},
+		{duration: 9 * time.Minute, rounding: internal.Rounding{Mode: RoundUp}, want: 9 * time.Minute},
+	}
+	for _, test := range tests {
+		t.Run(test.duration.String()+" "+test.rounding.Mode, func(t *testing.T) {
+			if got := Round(test.duration, test.rounding); got != test.want {
+				t.Errorf("got %s want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTimesheetRound(t *testing.T) {
+	date := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	timesheet := Timesheet{
+		{Date: date, Duration: 20 * time.Minute},
+		{Date: date, Duration: 20 * time.Minute},
+	}
+	entry := timesheet.Round(internal.Rounding{Unit: 30 * time.Minute, Mode: RoundUp, Scope: RoundEntry}).Total()
+	if entry != time.Hour {
+		t.Errorf("got %s want %s", entry, time.Hour)
+	}
+	day := timesheet.Round(internal.Rounding{Unit: 30 * time.Minute, Mode: RoundUp, Scope: RoundDay}).Total()
+	if day != time.Hour {
+		t.Errorf("got %s want %s", day, time.Hour)
+	}
+	day = timesheet.Round(internal.Rounding{Unit: 15 * time.Minute, Mode: RoundUp, Scope: RoundDay}).Total()
+	if day != 45*time.Minute {
+		t.Errorf("got %s want %s", day, 45*time.Minute)
+	}
+}
+
+func TestRoundBooking(t *testing.T) {
+	nearest := internal.Rounding{Unit: 15 * time.Minute, Mode: RoundNearest}
+	tests := []struct {
+		duration time.Duration
+		rounding internal.Rounding
+		want     time.Duration
+		err      bool
+	}{
+		{duration: 10 * time.Minute, rounding: nearest, want: 15 * time.Minute},
+		{duration: 5 * time.Minute, rounding: nearest, err: true},
+		{duration: 14 * time.Minute, rounding: internal.Rounding{Unit: 15 * time.Minute, Mode: RoundDown}, err: true},
+		{duration: 0, rounding: internal.Rounding{Mode: RoundUp}, err: true},
+		{duration: 5 * time.Minute, rounding: internal.Rounding{Mode: RoundNearest}, want: 5 * time.Minute},
+	}
+	for _, test := range tests {
+		t.Run(test.duration.String()+" "+test.rounding.Mode, func(t *testing.T) {
+			got, err := RoundBooking(test.duration, test.rounding)
+			if (err != nil) != test.err || got != test.want {
+				t.Errorf("got %s, %v want %s", got, err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Selection restricts efforts by worklog id, range of days, task and filters. Empty parts select every effort.
+type Selection struct {
+	Ids []string
+	// From and To are the first and the last day, zero for no bound
+	From time.Time
+	To   time.Time
+	Task Task
+	// Filters are matched like the filters of a timesheet
+	Filters []Filter
+}
+
+// Bounded tells, if the selection has a first and a last day.
+func (selection Selection) Bounded() bool {
+	return !selection.From.IsZero() && !selection.To.IsZero()
+}
+
+// Match tells, if the effort is part of the selection.
+func (selection Selection) Match(effort Effort) bool {
+	if len(selection.Ids) > 0 && !contains(selection.Ids, effort.Id) {
+		return false
+	}
+	if !selection.From.IsZero() && effort.Date.Before(selection.From) {
+		return false
+	}
+	if !selection.To.IsZero() && effort.Date.After(selection.To) {
+		return false
+	}
+	if selection.Task != "" && !strings.EqualFold(string(effort.Task), string(selection.Task)) {
+		return false
+	}
+	for _, filter := range selection.Filters {
+		if !filter.Match(effort) {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns the efforts of the selection.
+func (ts Timesheet) Select(selection Selection) Timesheet {
+	result := make(Timesheet, 0, len(ts))
+	for _, effort := range ts {
+		if selection.Match(effort) {
+			result = append(result, effort)
+		}
+	}
+	return result
+}
+
+// Stringers returns every effort as item of a confirmation.
+func (ts Timesheet) Stringers() []fmt.Stringer {
+	result := make([]fmt.Stringer, len(ts))
+	for i, effort := range ts {
+		result[i] = effort
+	}
+	return result
+}
+
+func (effort Effort) String() string {
+	return fmt.Sprintf("%s;%s;%s;%s", effort.Task, effort.Date.Format(IsoYearMonthDay), effort.Duration, effort.Description)
+}
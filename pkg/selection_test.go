@@ -0,0 +1,42 @@
+package pkg
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestSelectionMatch(t *testing.T) {
+	effort := Effort{
+		Id:          "10001",
+		Task:        "PROJ-1",
+		Description: "Daily meeting",
+		Date:        time.Date(2022, 8, 3, 0, 0, 0, 0, time.UTC),
+		Duration:    time.Hour,
+	}
+	day := func(day int) time.Time { return time.Date(2022, 8, day, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name      string
+		selection Selection
+		want      bool
+	}{
+		{name: "everything", selection: Selection{}, want: true},
+		{name: "id", selection: Selection{Ids: []string{"10000", "10001"}}, want: true},
+		{name: "other id", selection: Selection{Ids: []string{"10000"}}},
+		{name: "range", selection: Selection{From: day(1), To: day(5)}, want: true},
+		{name: "same day", selection: Selection{From: day(3), To: day(3)}, want: true},
+		{name: "before", selection: Selection{From: day(4), To: day(5)}},
+		{name: "after", selection: Selection{From: day(1), To: day(2)}},
+		{name: "task", selection: Selection{Task: "proj-1"}, want: true},
+		{name: "other task", selection: Selection{Task: "PROJ-2"}},
+		{name: "comment", selection: Selection{Filters: []Filter{{Key: "description", Pattern: regexp.MustCompile("(?i)meeting")}}}, want: true},
+		{name: "other comment", selection: Selection{Filters: []Filter{{Key: "description", Pattern: regexp.MustCompile("^Fix")}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.selection.Match(effort); got != test.want {
+				t.Errorf("got %v want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"crypto/subtle"
+	"eager/pkg"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	pathTimesheet = "/timesheet"
+	pathEfforts   = "/efforts"
+	// maxDays is the longest range of a timesheet request
+	maxDays = 366
+)
+
+//go:embed dashboard.html
+var dashboard []byte
+
+// Effort is the representation of an effort inside the api.
+// The id is the task and the id of the worklog separated by a colon.
+type Effort struct {
+	Id          string            `json:"id,omitempty"`
+	User        string            `json:"user,omitempty"`
+	Project     string            `json:"project,omitempty"`
+	Task        string            `json:"task"`
+	Description string            `json:"description,omitempty"`
+	Date        string            `json:"date"`
+	Duration    string            `json:"duration"`
+	Hours       float64           `json:"hours"`
+	Start       *time.Time        `json:"start,omitempty"`
+	Billing     string            `json:"billing,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+type errorResult struct {
+	Error string `json:"error"`
+}
+
+// New returns the handler of the api and the dashboard for the provider.
+// Efforts are only added and removed with the token as bearer token, without token the api is read-only.
+func New(provider pkg.Provider, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/" {
+			writeError(writer, http.StatusNotFound, fmt.Errorf("found no %s", request.URL.Path))
+			return
+		}
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, err := writer.Write(dashboard)
+		if err != nil {
+			log.Println("Could not write dashboard.", err)
+		}
+	})
+	mux.HandleFunc(pathTimesheet, func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet {
+			writeError(writer, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", request.Method))
+			return
+		}
+		timesheet(provider, writer, request)
+	})
+	mux.HandleFunc(pathEfforts, func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writeError(writer, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", request.Method))
+			return
+		}
+		if !authorized(writer, request, token) {
+			return
+		}
+		// Forms of other sites cannot send json, so a cross-site request fails here
+		if mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+			writeError(writer, http.StatusUnsupportedMediaType, fmt.Errorf("the content type must be application/json"))
+			return
+		}
+		add(provider, writer, request)
+	})
+	mux.HandleFunc(pathEfforts+"/", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodDelete {
+			writeError(writer, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", request.Method))
+			return
+		}
+		if !authorized(writer, request, token) {
+			return
+		}
+		remove(provider, writer, strings.TrimPrefix(request.URL.Path, pathEfforts+"/"))
+	})
+	return mux
+}
+
+// authorized checks the bearer token of a request, that changes the worklog, and writes the error otherwise.
+func authorized(writer http.ResponseWriter, request *http.Request, token string) bool {
+	if token == "" {
+		writeError(writer, http.StatusForbidden, fmt.Errorf("the server is read-only"))
+		return false
+	}
+	given := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+		writer.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(writer, http.StatusUnauthorized, fmt.Errorf("the token is missing or wrong"))
+		return false
+	}
+	return true
+}
+
+// timesheet writes the efforts between both days, including the last day. Without days, the current month is used.
+func timesheet(provider pkg.Provider, writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	now := time.Now()
+	from, to := pkg.GetTimeRange(now.Year(), now.Month())
+	to = to.AddDate(0, 0, -1)
+	var err error
+	if value := query.Get("from"); value != "" {
+		from, err = time.Parse(pkg.IsoYearMonthDay, value)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Errorf("not a valid date '%s'", value))
+			return
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		to, err = time.Parse(pkg.IsoYearMonthDay, value)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Errorf("not a valid date '%s'", value))
+			return
+		}
+	}
+	if to.Before(from) {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("the end %s is before the start %s", to.Format(pkg.IsoYearMonthDay), from.Format(pkg.IsoYearMonthDay)))
+		return
+	}
+	if to.Sub(from) >= maxDays*24*time.Hour {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("the range must not exceed %d days", maxDays))
+		return
+	}
+	var users []string
+	for _, value := range query["user"] {
+		for _, user := range strings.Split(value, ",") {
+			if user = strings.TrimSpace(user); user != "" {
+				users = append(users, user)
+			}
+		}
+	}
+	bulk, ok := provider.(pkg.BulkProvider)
+	if len(users) > 0 && !ok {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("the store has no worklog of other users"))
+		return
+	}
+
+	result := []Effort{}
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(to); month = month.AddDate(0, 1, 0) {
+		var timesheet pkg.Timesheet
+		if len(users) > 0 {
+			timesheet, err = bulk.BulkTimesheet(month.Year(), month.Month(), pkg.Users(users))
+		} else {
+			timesheet, err = provider.Timesheet(month.Year(), month.Month())
+		}
+		if err != nil {
+			writeError(writer, http.StatusBadGateway, err)
+			return
+		}
+		for _, effort := range timesheet {
+			if effort.Date.Before(from) || effort.Date.After(to) {
+				continue
+			}
+			result = append(result, newEffort(effort))
+		}
+	}
+	writeJson(writer, http.StatusOK, result)
+}
+
+func add(provider pkg.Provider, writer http.ResponseWriter, request *http.Request) {
+	var body Effort
+	err := json.NewDecoder(request.Body).Decode(&body)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("not a valid effort. %s", err.Error()))
+		return
+	}
+	if body.Task == "" {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("the task is missing"))
+		return
+	}
+	date, err := time.Parse(pkg.IsoYearMonthDay, body.Date)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("not a valid date '%s'", body.Date))
+		return
+	}
+	duration, err := time.ParseDuration(body.Duration)
+	if err != nil || duration <= 0 {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("not a valid duration '%s'", body.Duration))
+		return
+	}
+	effort := pkg.Effort{
+		Task:        pkg.Task(body.Task),
+		Description: pkg.Description(body.Description),
+		Date:        date,
+		Duration:    duration,
+		Billing:     pkg.Billing(body.Billing),
+		Attributes:  body.Attributes,
+	}
+	if body.Start != nil {
+		effort.Start = *body.Start
+	}
+	effort.Id, err = pkg.Batched(provider).Add(effort)
+	if err != nil {
+		writeError(writer, http.StatusBadGateway, err)
+		return
+	}
+	writeJson(writer, http.StatusCreated, newEffort(effort))
+}
+
+func remove(provider pkg.Provider, writer http.ResponseWriter, id string) {
+	separator := strings.LastIndex(id, ":")
+	if separator <= 0 || separator == len(id)-1 {
+		writeError(writer, http.StatusBadRequest, fmt.Errorf("not a valid id '%s'", id))
+		return
+	}
+	err := pkg.Batched(provider).Remove(pkg.Effort{
+		Task: pkg.Task(id[:separator]),
+		Id:   id[separator+1:],
+	})
+	if err != nil {
+		writeError(writer, http.StatusBadGateway, err)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func newEffort(effort pkg.Effort) Effort {
+	result := Effort{
+		Project:     string(effort.Project),
+		Task:        string(effort.Task),
+		Description: string(effort.Description),
+		Date:        effort.Date.Format(pkg.IsoYearMonthDay),
+		Duration:    effort.Duration.String(),
+		Hours:       effort.Duration.Hours(),
+		Billing:     string(effort.Billing),
+		Attributes:  effort.Attributes,
+	}
+	if effort.Id != "" {
+		result.Id = string(effort.Task) + ":" + effort.Id
+	}
+	if effort.User != nil {
+		result.User = effort.User.DisplayName
+	}
+	if !effort.Start.IsZero() {
+		start := effort.Start
+		result.Start = &start
+	}
+	return result
+}
+
+func writeJson(writer http.ResponseWriter, status int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	err := json.NewEncoder(writer).Encode(value)
+	if err != nil {
+		log.Println("Could not write response.", err)
+	}
+}
+
+func writeError(writer http.ResponseWriter, status int, err error) {
+	writeJson(writer, status, errorResult{Error: err.Error()})
+}
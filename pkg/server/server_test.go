@@ -0,0 +1,122 @@
+package server
+
+import (
+	"bytes"
+	"eager/internal/fake"
+	"eager/pkg"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestServer(t *testing.T) {
+	date := time.Date(2022, time.August, 31, 0, 0, 0, 0, time.UTC)
+	provider := &fake.Provider{Efforts: pkg.Timesheet{
+		{Id: "10000", Task: "PROJ-1", Date: date.AddDate(0, 0, -1), Duration: time.Hour},
+		{Id: "10001", Task: "PROJ-1", Date: date, Duration: 90 * time.Minute},
+		{Id: "10002", Task: "PROJ-2", Date: date.AddDate(0, 0, 1), Duration: 30 * time.Minute},
+		{Id: "10003", Task: "PROJ-2", Date: date.AddDate(0, 0, 2), Duration: 30 * time.Minute},
+	}, FailTask: "FAIL-1"}
+	server := httptest.NewServer(New(provider, "secret"))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/timesheet?from=2022-08-31&to=2022-09-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var efforts []Effort
+	_ = json.NewDecoder(response.Body).Decode(&efforts)
+	_ = response.Body.Close()
+	want := []Effort{
+		{Id: "PROJ-1:10001", Task: "PROJ-1", Date: "2022-08-31", Duration: "1h30m0s", Hours: 1.5},
+		{Id: "PROJ-2:10002", Task: "PROJ-2", Date: "2022-09-01", Duration: "30m0s", Hours: 0.5},
+	}
+	if !reflect.DeepEqual(efforts, want) {
+		t.Errorf("got %v want %v", efforts, want)
+	}
+
+	write := map[string]string{"Authorization": "Bearer secret", "Content-Type": "application/json"}
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		headers map[string]string
+		body    string
+		status  int
+	}{
+		{name: "users without bulk", method: http.MethodGet, path: "/timesheet?user=Jane", status: http.StatusBadRequest},
+		{name: "invalid date", method: http.MethodGet, path: "/timesheet?from=31.08.2022", status: http.StatusBadRequest},
+		{name: "range too long", method: http.MethodGet, path: "/timesheet?from=2021-08-01&to=2022-08-31", status: http.StatusBadRequest},
+		{name: "add", method: http.MethodPost, path: "/efforts", headers: write, body: `{"task":"PROJ-3","date":"2022-08-01","duration":"2h"}`, status: http.StatusCreated},
+		{name: "add without token", method: http.MethodPost, path: "/efforts", headers: map[string]string{"Content-Type": "application/json"}, body: `{"task":"PROJ-3","date":"2022-08-01","duration":"2h"}`, status: http.StatusUnauthorized},
+		{name: "add with wrong token", method: http.MethodPost, path: "/efforts", headers: map[string]string{"Authorization": "Bearer guess", "Content-Type": "application/json"}, body: `{"task":"PROJ-3","date":"2022-08-01","duration":"2h"}`, status: http.StatusUnauthorized},
+		{name: "add as form", method: http.MethodPost, path: "/efforts", headers: map[string]string{"Authorization": "Bearer secret", "Content-Type": "text/plain"}, body: `{"task":"PROJ-3","date":"2022-08-01","duration":"2h"}`, status: http.StatusUnsupportedMediaType},
+		{name: "add without task", method: http.MethodPost, path: "/efforts", headers: write, body: `{"date":"2022-08-01","duration":"2h"}`, status: http.StatusBadRequest},
+		{name: "add failed", method: http.MethodPost, path: "/efforts", headers: write, body: `{"task":"FAIL-1","date":"2022-08-01","duration":"2h"}`, status: http.StatusBadGateway},
+		{name: "remove without token", method: http.MethodDelete, path: "/efforts/PROJ-1:10000", status: http.StatusUnauthorized},
+		{name: "remove", method: http.MethodDelete, path: "/efforts/PROJ-1:10000", headers: write, status: http.StatusNoContent},
+		{name: "remove without task", method: http.MethodDelete, path: "/efforts/10000", headers: write, status: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodGet, path: "/efforts", status: http.StatusMethodNotAllowed},
+		{name: "dashboard", method: http.MethodGet, path: "/", status: http.StatusOK},
+		{name: "unknown", method: http.MethodGet, path: "/unknown", status: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, _ := http.NewRequest(test.method, server.URL+test.path, bytes.NewBufferString(test.body))
+			for name, value := range test.headers {
+				request.Header.Set(name, value)
+			}
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = response.Body.Close()
+			if response.StatusCode != test.status {
+				t.Errorf("got %d want %d", response.StatusCode, test.status)
+			}
+		})
+	}
+	if len(provider.Added) != 1 || provider.Added[0].Task != "PROJ-3" || provider.Added[0].Duration != 2*time.Hour {
+		t.Errorf("got %v", provider.Added)
+	}
+	if len(provider.Removed) != 1 || provider.Removed[0].Task != "PROJ-1" || provider.Removed[0].Id != "10000" {
+		t.Errorf("got %v", provider.Removed)
+	}
+}
+
+func TestServerWrite(t *testing.T) {
+	provider := &fake.Provider{}
+	server := httptest.NewServer(New(provider, "secret"))
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL+"/efforts", bytes.NewBufferString(`{"task":"PROJ-3","date":"2022-08-01","duration":"2h"}`))
+	request.Header.Set("Authorization", "Bearer secret")
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var effort Effort
+	_ = json.NewDecoder(response.Body).Decode(&effort)
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusCreated || effort.Id != "PROJ-3:1" {
+		t.Errorf("got %d %v want the id of the new worklog", response.StatusCode, effort)
+	}
+
+	// Without token, the server is read-only
+	readOnly := httptest.NewServer(New(provider, ""))
+	defer readOnly.Close()
+	request, _ = http.NewRequest(http.MethodDelete, readOnly.URL+"/efforts/PROJ-3:1", nil)
+	request.Header.Set("Authorization", "Bearer ")
+	response, err = http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusForbidden || len(provider.Removed) > 0 {
+		t.Errorf("got %d want %d", response.StatusCode, http.StatusForbidden)
+	}
+}
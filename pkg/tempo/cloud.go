@@ -0,0 +1,218 @@
+package tempo
+
+import (
+	"bytes"
+	"eager/pkg"
+	"eager/pkg/jira/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	cloudWorklogsUrl      = "worklogs/user/%s?from=%s&to=%s&offset=0&limit=%d"
+	cloudAddWorklogUrl    = "worklogs"
+	cloudWorklogUrl       = "worklogs/%s"
+	cloudRemoveWorklogUrl = "worklogs/%s"
+	cloudLimit            = 100
+	// cloudIssues is the number of issue ids per Jira search
+	cloudIssues = 50
+)
+
+// cloudApi is Tempo Cloud, that knows the issues only by id. The keys and projects are read from Jira.
+type cloudApi struct {
+	Client *http.Client
+	Server *url.URL
+	Token  string
+	Jira   model.IssueReader
+	// DryRun prints every change instead of sending it, if it is set
+	DryRun io.Writer
+}
+
+func (api cloudApi) Worklogs(accounts []model.Account, fromDate, toDate time.Time, worklogFunc func(Worklog)) error {
+	var worklogs []*cloudWorklog
+	for _, account := range accounts {
+		next, _ := api.Server.Parse(fmt.Sprintf(cloudWorklogsUrl, url.PathEscape(string(account)), fromDate.Format(pkg.IsoYearMonthDay), toDate.Format(pkg.IsoYearMonthDay), cloudLimit))
+		for next != nil {
+			var result cloudWorklogQueryResult
+			err := api.request(http.MethodGet, next, nil, http.StatusOK, &result)
+			if err != nil {
+				return err
+			}
+			worklogs = append(worklogs, result.Results...)
+			next = nil
+			if result.Metadata.Next != "" {
+				next, err = url.Parse(result.Metadata.Next)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	issues, err := api.issues(worklogs)
+	if err != nil {
+		return err
+	}
+	for _, worklog := range worklogs {
+		result, err := worklog.worklog(issues)
+		if err != nil {
+			return err
+		}
+		worklogFunc(result)
+	}
+	return nil
+}
+
+func (api cloudApi) Worklog(id string) (Worklog, error) {
+	worklogUrl, _ := api.Server.Parse(fmt.Sprintf(cloudWorklogUrl, url.PathEscape(id)))
+	var worklog cloudWorklog
+	err := api.request(http.MethodGet, worklogUrl, nil, http.StatusOK, &worklog)
+	if err != nil {
+		return Worklog{}, err
+	}
+	issues, err := api.issues([]*cloudWorklog{&worklog})
+	if err != nil {
+		return Worklog{}, err
+	}
+	return worklog.worklog(issues)
+}
+
+// issues returns the Jira issue of every worklog by id.
+func (api cloudApi) issues(worklogs []*cloudWorklog) (map[string]model.Issue, error) {
+	result := map[string]model.Issue{}
+	var ids []model.IssueKey
+	for _, worklog := range worklogs {
+		id := strconv.Itoa(worklog.Issue.Id)
+		if _, ok := result[id]; !ok {
+			result[id] = nil
+			ids = append(ids, model.IssueKey(id))
+		}
+	}
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > cloudIssues {
+			n = cloudIssues
+		}
+		err := api.Jira.Issues(new(model.Jql).Keys(ids[:n]...), nil, func(issue model.Issue) {
+			result[issue.Id()] = issue
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids = ids[n:]
+	}
+	return result, nil
+}
+
+func (api cloudApi) AddWorklog(worklog Worklog) (string, error) {
+	if worklog.IssueId == "" {
+		err := api.Jira.Issues(new(model.Jql).Keys(worklog.Issue), nil, func(issue model.Issue) {
+			worklog.IssueId = issue.Id()
+		})
+		if err != nil {
+			return "", err
+		}
+		if worklog.IssueId == "" {
+			return "", fmt.Errorf("found no issue %s", worklog.Issue)
+		}
+	}
+	issueId, err := strconv.Atoi(worklog.IssueId)
+	if err != nil {
+		return "", fmt.Errorf("not a valid issue id '%s'", worklog.IssueId)
+	}
+	item := cloudWorklogItem{
+		AuthorAccountId:  worklog.Account,
+		IssueId:          issueId,
+		TimeSpentSeconds: int(worklog.Duration.Truncate(time.Second).Seconds()),
+		BillableSeconds:  int(worklog.Billable.Truncate(time.Second).Seconds()),
+		StartDate:        worklog.Start.Format(pkg.IsoYearMonthDay),
+		StartTime:        worklog.Start.Format("15:04:05"),
+		Description:      string(worklog.Description),
+	}
+	for key, value := range worklog.Attributes {
+		item.Attributes = append(item.Attributes, &cloudAttribute{Key: key, Value: value})
+	}
+	body, _ := json.Marshal(item)
+	worklogUrl, _ := api.Server.Parse(cloudAddWorklogUrl)
+	if api.DryRun != nil {
+		pkg.PrintRequest(api.DryRun, http.MethodPost, worklogUrl, body)
+		return "", nil
+	}
+	var result cloudWorklog
+	err = api.request(http.MethodPost, worklogUrl, body, http.StatusOK, &result)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(result.TempoWorklogId), nil
+}
+
+func (api cloudApi) RemoveWorklog(id string) error {
+	worklogUrl, _ := api.Server.Parse(fmt.Sprintf(cloudRemoveWorklogUrl, url.PathEscape(id)))
+	if api.DryRun != nil {
+		pkg.PrintRequest(api.DryRun, http.MethodDelete, worklogUrl, nil)
+		return nil
+	}
+	return api.request(http.MethodDelete, worklogUrl, nil, http.StatusNoContent, nil)
+}
+
+// request sends the body and reads the response into the result, if there is one.
+func (api cloudApi) request(method string, path *url.URL, body []byte, status int, result interface{}) error {
+	response, err := pkg.CreateTokenJsonRequest(api.Client, method, path, api.Token, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			log.Println("Response could not be closed.", err)
+		}
+	}()
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != status {
+		return fmt.Errorf(response.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
+
+// worklog returns the worklog with the key and project of its issue, if the issue is known.
+func (worklog *cloudWorklog) worklog(issues map[string]model.Issue) (Worklog, error) {
+	issueId := strconv.Itoa(worklog.Issue.Id)
+	start, err := time.Parse(pkg.IsoYearMonthDay+" 15:04:05", worklog.StartDate+" "+worklog.StartTime)
+	if err != nil {
+		return Worklog{}, fmt.Errorf("invalid start of worklog %d. %s", worklog.TempoWorklogId, err.Error())
+	}
+	attributes := map[string]string{}
+	for _, attribute := range worklog.Attributes.Values {
+		attributes[attribute.Key] = attribute.Value
+	}
+	result := Worklog{
+		Id:          strconv.Itoa(worklog.TempoWorklogId),
+		Account:     worklog.Author.AccountId,
+		IssueId:     issueId,
+		Issue:       model.IssueKey(issueId),
+		Start:       start,
+		Duration:    time.Duration(worklog.TimeSpentSeconds) * time.Second,
+		Billable:    time.Duration(worklog.BillableSeconds) * time.Second,
+		Description: pkg.Description(worklog.Description),
+		Attributes:  attributes,
+	}
+	if issue := issues[issueId]; issue != nil {
+		result.Issue = issue.Key()
+		result.Project = issue.Project()
+	}
+	return result, nil
+}
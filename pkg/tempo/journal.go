@@ -0,0 +1,137 @@
+package tempo
+
+import (
+	"eager/pkg"
+	"eager/pkg/jira/model"
+	"eager/pkg/journal"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+const store = "tempo"
+
+// journalApi records every added and removed worklog inside the journal.
+type journalApi struct {
+	Api
+	journal *journal.Journal
+	server  string
+	batch   string
+	// undo is the id of the entry, that is reversed by the changes of this api
+	undo int
+}
+
+// journaled returns the api, that records its changes. Changes of a dry run are not recorded.
+func journaled(api Api, server *url.URL, changes *journal.Changes) Api {
+	if !changes.Recorded() {
+		return api
+	}
+	return &journalApi{Api: api, journal: changes.Journal, server: server.String(), batch: changes.Batch}
+}
+
+func (api *journalApi) AddWorklog(worklog Worklog) (string, error) {
+	id, err := api.Api.AddWorklog(worklog)
+	if err != nil {
+		return id, err
+	}
+	worklog.Id = id
+	api.record(journal.OperationAdd, worklog)
+	return id, nil
+}
+
+// RemoveWorklog reads the worklog first to keep everything necessary to add it again.
+func (api *journalApi) RemoveWorklog(id string) error {
+	worklog, err := api.Api.Worklog(id)
+	if err != nil {
+		return fmt.Errorf("could not get worklog. %s", err.Error())
+	}
+	err = api.Api.RemoveWorklog(id)
+	if err != nil {
+		return err
+	}
+	worklog.Id = id
+	api.record(journal.OperationRemove, worklog)
+	return nil
+}
+
+// record appends the change. The change is already done, so a broken journal only gets logged.
+func (api *journalApi) record(operation journal.Operation, worklog Worklog) {
+	_, err := api.journal.Append(journal.Entry{
+		Store:       store,
+		Server:      api.server,
+		Operation:   operation,
+		Issue:       string(worklog.Issue),
+		Worklog:     worklog.Id,
+		Account:     string(worklog.Account),
+		Start:       worklog.Start,
+		Duration:    worklog.Duration,
+		Billable:    worklog.Billable,
+		Description: string(worklog.Description),
+		Attributes:  worklog.Attributes,
+		Undo:        api.undo,
+		Batch:       api.batch,
+	})
+	if err != nil {
+		log.Println("Could not write journal.", err)
+	}
+}
+
+// Undo reverses the entry of the journal. Without id, every entry of the last command is reversed.
+// The reversals are appended to the journal and the reversed entries are returned.
+// Tempo adds removed worklogs again for the same user.
+func Undo(client *http.Client, server *url.URL, userinfo *url.Userinfo, token, tempoUrl string, changes *journal.Changes, id int) ([]journal.Entry, error) {
+	if changes == nil || changes.Journal == nil {
+		return nil, fmt.Errorf("there is no journal")
+	}
+	entries, err := changes.Journal.Entries()
+	if err != nil {
+		return nil, fmt.Errorf("could not read journal. %s", err.Error())
+	}
+	var batch []journal.Entry
+	if id == 0 {
+		var entry journal.Entry
+		entry, err = journal.Last(entries, store, server.String())
+		batch = journal.Batch(entries, entry)
+	} else {
+		var entry journal.Entry
+		entry, err = journal.Find(entries, store, server.String(), id)
+		batch = []journal.Entry{entry}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	api, _, err := newApi(client, server, userinfo, token, tempoUrl, changes)
+	if err != nil {
+		return nil, err
+	}
+	var reversed []journal.Entry
+	for _, entry := range batch {
+		if recorder, ok := api.(*journalApi); ok {
+			recorder.undo = entry.Id
+		}
+		switch entry.Reverse() {
+		case journal.OperationRemove:
+			err = api.RemoveWorklog(entry.Worklog)
+			if err != nil {
+				return reversed, fmt.Errorf("could not remove effort. %s", err.Error())
+			}
+		case journal.OperationAdd:
+			_, err = api.AddWorklog(Worklog{
+				Account:     model.Account(entry.Account),
+				Issue:       model.IssueKey(entry.Issue),
+				Start:       entry.Start,
+				Duration:    entry.Duration,
+				Billable:    entry.Billable,
+				Description: pkg.Description(entry.Description),
+				Attributes:  entry.Attributes,
+			})
+			if err != nil {
+				return reversed, fmt.Errorf("could not add effort. %s", err.Error())
+			}
+		}
+		reversed = append(reversed, entry)
+	}
+	return reversed, nil
+}
@@ -0,0 +1,85 @@
+package tempo
+
+import (
+	"eager/pkg/jira/model"
+)
+
+type cloudWorklogQueryResult struct {
+	Metadata struct {
+		Count  int    `json:"count"`
+		Offset int    `json:"offset"`
+		Limit  int    `json:"limit"`
+		Next   string `json:"next"`
+	} `json:"metadata"`
+	Results []*cloudWorklog `json:"results"`
+}
+
+type cloudWorklog struct {
+	TempoWorklogId int `json:"tempoWorklogId"`
+	Issue          struct {
+		Id int `json:"id"`
+	} `json:"issue"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+	BillableSeconds  int    `json:"billableSeconds"`
+	StartDate        string `json:"startDate"`
+	StartTime        string `json:"startTime"`
+	Description      string `json:"description"`
+	Author           struct {
+		AccountId model.Account `json:"accountId"`
+	} `json:"author"`
+	Attributes struct {
+		Values []*cloudAttribute `json:"values"`
+	} `json:"attributes"`
+}
+
+type cloudAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type cloudWorklogItem struct {
+	AuthorAccountId  model.Account     `json:"authorAccountId"`
+	IssueId          int               `json:"issueId"`
+	TimeSpentSeconds int               `json:"timeSpentSeconds"`
+	BillableSeconds  int               `json:"billableSeconds"`
+	StartDate        string            `json:"startDate"`
+	StartTime        string            `json:"startTime"`
+	Description      string            `json:"description"`
+	Attributes       []*cloudAttribute `json:"attributes,omitempty"`
+}
+
+type serverWorklogQuery struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Worker []model.Account `json:"worker"`
+}
+
+type serverWorklog struct {
+	TempoWorklogId   int           `json:"tempoWorklogId"`
+	Comment          string        `json:"comment"`
+	TimeSpentSeconds int           `json:"timeSpentSeconds"`
+	BillableSeconds  int           `json:"billableSeconds"`
+	Started          string        `json:"started"`
+	Worker           model.Account `json:"worker"`
+	Issue            struct {
+		Id         int    `json:"id"`
+		Key        string `json:"key"`
+		ProjectKey string `json:"projectKey"`
+	} `json:"issue"`
+	Attributes map[string]*serverAttribute `json:"attributes"`
+}
+
+type serverAttribute struct {
+	WorkAttributeId int    `json:"workAttributeId,omitempty"`
+	Value           string `json:"value"`
+}
+
+type serverWorklogItem struct {
+	Worker           model.Account               `json:"worker"`
+	OriginTaskId     string                      `json:"originTaskId"`
+	Started          string                      `json:"started"`
+	TimeSpentSeconds int                         `json:"timeSpentSeconds"`
+	BillableSeconds  int                         `json:"billableSeconds"`
+	Comment          string                      `json:"comment"`
+	Attributes       map[string]*serverAttribute `json:"attributes,omitempty"`
+}
@@ -0,0 +1,114 @@
+package tempo
+
+import (
+	"eager/pkg"
+	"eager/pkg/jira"
+	"eager/pkg/jira/model"
+	"eager/pkg/journal"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Provider is the worklog of the current user inside Tempo.
+type Provider struct {
+	Client   *http.Client
+	Server   *url.URL
+	Userinfo *url.Userinfo
+	Token    string
+	TempoUrl string
+	// Changes tell, how worklogs are changed
+	Changes *journal.Changes
+}
+
+func NewProvider(client *http.Client, server *url.URL, userinfo *url.Userinfo, token, tempoUrl string, changes *journal.Changes) *Provider {
+	return &Provider{
+		Client:   client,
+		Server:   server,
+		Userinfo: userinfo,
+		Token:    token,
+		TempoUrl: tempoUrl,
+		Changes:  changes,
+	}
+}
+
+// Batch returns the provider, that records its changes inside a new batch of the journal.
+func (provider *Provider) Batch() pkg.Provider {
+	batched := *provider
+	batched.Changes = provider.Changes.Next()
+	return &batched
+}
+
+func (provider *Provider) Timesheet(year int, month time.Month) (pkg.Timesheet, error) {
+	api, jiraApi, err := newApi(provider.Client, provider.Server, provider.Userinfo, provider.Token, provider.TempoUrl, provider.Changes)
+	if err != nil {
+		return nil, err
+	}
+	return worklogs(api, jiraApi, year, month, nil, nil)
+}
+
+func (provider *Provider) BulkTimesheet(year int, month time.Month, users []*pkg.User) (pkg.Timesheet, error) {
+	api, jiraApi, err := newApi(provider.Client, provider.Server, provider.Userinfo, provider.Token, provider.TempoUrl, provider.Changes)
+	if err != nil {
+		return nil, err
+	}
+	return worklogs(api, jiraApi, year, month, users, nil)
+}
+
+// Add books the effort with its billable duration. Only attributes with the key of a work attribute like _Account_ are kept.
+func (provider *Provider) Add(effort pkg.Effort) (string, error) {
+	api, jiraApi, err := newApi(provider.Client, provider.Server, provider.Userinfo, provider.Token, provider.TempoUrl, provider.Changes)
+	if err != nil {
+		return "", err
+	}
+	account, location, err := jiraApi.Me()
+	if err != nil {
+		return "", fmt.Errorf("could not get user. %s", err.Error())
+	}
+	start := effort.Start
+	if start.IsZero() {
+		year, month, day := effort.Date.Date()
+		start = jira.AdjustDateTime(location, effort.Duration, year, month, day)
+	}
+	attributes := map[string]string{}
+	for key, value := range effort.Attributes {
+		if len(key) > 2 && strings.HasPrefix(key, "_") && strings.HasSuffix(key, "_") {
+			attributes[key] = value
+		}
+	}
+	id, err := api.AddWorklog(Worklog{
+		Account:     account,
+		Issue:       model.IssueKey(effort.Task),
+		Start:       time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, time.UTC),
+		Duration:    effort.Duration,
+		Billable:    effort.BillableDuration(),
+		Description: effort.Description,
+		Attributes:  attributes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not add effort. %s", err.Error())
+	}
+	return id, nil
+}
+
+func (provider *Provider) Remove(effort pkg.Effort) error {
+	if effort.Id == "" {
+		return fmt.Errorf("effort of %s has no id", effort.Task)
+	}
+	api, _, err := newApi(provider.Client, provider.Server, provider.Userinfo, provider.Token, provider.TempoUrl, provider.Changes)
+	if err != nil {
+		return err
+	}
+	err = api.RemoveWorklog(effort.Id)
+	if err != nil {
+		return fmt.Errorf("could not remove effort. %s", err.Error())
+	}
+	return nil
+}
+
+// ValidateTask checks, that the issue of the task exists inside Jira.
+func (provider *Provider) ValidateTask(task pkg.Task) error {
+	return jira.NewProvider(provider.Client, provider.Server, provider.Userinfo, nil).ValidateTask(task)
+}
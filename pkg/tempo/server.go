@@ -0,0 +1,155 @@
+package tempo
+
+import (
+	"bytes"
+	"eager/pkg"
+	"eager/pkg/jira/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	serverSearchWorklogUrl = "worklogs/search"
+	serverAddWorklogUrl    = "worklogs"
+	serverWorklogUrl       = "worklogs/%s"
+	serverRemoveWorklogUrl = "worklogs/%s"
+	serverDateTime         = "2006-01-02 15:04:05.000"
+)
+
+// serverApi is Tempo Server, that is part of Jira and uses the same authentication.
+type serverApi struct {
+	Client   *http.Client
+	Server   *url.URL
+	Userinfo *url.Userinfo
+	// DryRun prints every change instead of sending it, if it is set
+	DryRun io.Writer
+}
+
+func (api serverApi) Worklogs(accounts []model.Account, fromDate, toDate time.Time, worklogFunc func(Worklog)) error {
+	body, _ := json.Marshal(serverWorklogQuery{
+		From:   fromDate.Format(pkg.IsoYearMonthDay),
+		To:     toDate.Format(pkg.IsoYearMonthDay),
+		Worker: accounts,
+	})
+	searchUrl, _ := api.Server.Parse(serverSearchWorklogUrl)
+	var result []*serverWorklog
+	err := api.request(http.MethodPost, searchUrl, body, http.StatusOK, &result)
+	if err != nil {
+		return err
+	}
+	for _, worklog := range result {
+		result, err := worklog.worklog()
+		if err != nil {
+			return err
+		}
+		worklogFunc(result)
+	}
+	return nil
+}
+
+func (api serverApi) Worklog(id string) (Worklog, error) {
+	worklogUrl, _ := api.Server.Parse(fmt.Sprintf(serverWorklogUrl, url.PathEscape(id)))
+	var worklog serverWorklog
+	err := api.request(http.MethodGet, worklogUrl, nil, http.StatusOK, &worklog)
+	if err != nil {
+		return Worklog{}, err
+	}
+	return worklog.worklog()
+}
+
+func (api serverApi) AddWorklog(worklog Worklog) (string, error) {
+	item := serverWorklogItem{
+		Worker:           worklog.Account,
+		OriginTaskId:     string(worklog.Issue),
+		Started:          worklog.Start.Format(serverDateTime),
+		TimeSpentSeconds: int(worklog.Duration.Truncate(time.Second).Seconds()),
+		BillableSeconds:  int(worklog.Billable.Truncate(time.Second).Seconds()),
+		Comment:          string(worklog.Description),
+	}
+	if len(worklog.Attributes) > 0 {
+		item.Attributes = map[string]*serverAttribute{}
+		for key, value := range worklog.Attributes {
+			item.Attributes[key] = &serverAttribute{Value: value}
+		}
+	}
+	body, _ := json.Marshal(item)
+	worklogUrl, _ := api.Server.Parse(serverAddWorklogUrl)
+	if api.DryRun != nil {
+		pkg.PrintRequest(api.DryRun, http.MethodPost, worklogUrl, body)
+		return "", nil
+	}
+	var result []*serverWorklog
+	err := api.request(http.MethodPost, worklogUrl, body, http.StatusOK, &result)
+	if err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", nil
+	}
+	return strconv.Itoa(result[0].TempoWorklogId), nil
+}
+
+func (api serverApi) RemoveWorklog(id string) error {
+	worklogUrl, _ := api.Server.Parse(fmt.Sprintf(serverRemoveWorklogUrl, url.PathEscape(id)))
+	if api.DryRun != nil {
+		pkg.PrintRequest(api.DryRun, http.MethodDelete, worklogUrl, nil)
+		return nil
+	}
+	return api.request(http.MethodDelete, worklogUrl, nil, http.StatusNoContent, nil)
+}
+
+// request sends the body and reads the response into the result, if there is one.
+func (api serverApi) request(method string, path *url.URL, body []byte, status int, result interface{}) error {
+	response, err := pkg.CreateJsonRequest(api.Client, method, path, api.Userinfo, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			log.Println("Response could not be closed.", err)
+		}
+	}()
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != status {
+		return fmt.Errorf(response.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (worklog *serverWorklog) worklog() (Worklog, error) {
+	start, err := time.Parse(serverDateTime, worklog.Started)
+	if err != nil {
+		return Worklog{}, fmt.Errorf("invalid start of worklog %d. %s", worklog.TempoWorklogId, err.Error())
+	}
+	attributes := map[string]string{}
+	for key, attribute := range worklog.Attributes {
+		attributes[key] = attribute.Value
+	}
+	return Worklog{
+		Id:          strconv.Itoa(worklog.TempoWorklogId),
+		Account:     worklog.Worker,
+		IssueId:     strconv.Itoa(worklog.Issue.Id),
+		Issue:       model.IssueKey(worklog.Issue.Key),
+		Project:     pkg.Project(worklog.Issue.ProjectKey),
+		Start:       start,
+		Duration:    time.Duration(worklog.TimeSpentSeconds) * time.Second,
+		Billable:    time.Duration(worklog.BillableSeconds) * time.Second,
+		Description: pkg.Description(worklog.Comment),
+		Attributes:  attributes,
+	}, nil
+}
@@ -0,0 +1,250 @@
+package tempo
+
+import (
+	"eager/pkg"
+	"eager/pkg/jira"
+	"eager/pkg/jira/model"
+	"eager/pkg/journal"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	CloudUrl   = "https://api.tempo.io/4/"
+	serverPath = "/rest/tempo-timesheets/4/"
+)
+
+// Api of Tempo Cloud or Tempo Server.
+type Api interface {
+	// Worklogs calls the function for every worklog of the accounts between both days, including the last day.
+	Worklogs(accounts []model.Account, fromDate, toDate time.Time, worklogFunc func(Worklog)) error
+	// Worklog returns the worklog with the id
+	Worklog(id string) (Worklog, error)
+	// AddWorklog returns the id of the new worklog
+	AddWorklog(worklog Worklog) (string, error)
+	RemoveWorklog(id string) error
+}
+
+// Worklog is a worklog of Tempo with its billable duration and work attributes.
+type Worklog struct {
+	Id      string
+	Account model.Account
+	// IssueId is the numeric id of the issue
+	IssueId string
+	Issue   model.IssueKey
+	Project pkg.Project
+	// Start is the local date and time of the worker without time zone
+	Start       time.Time
+	Duration    time.Duration
+	Billable    time.Duration
+	Description pkg.Description
+	// Attributes are the values of the work attributes by key
+	Attributes map[string]string
+}
+
+// newApi returns the Tempo api, that changes worklogs as given by the changes, and the Jira api of the deployment.
+// Tempo Cloud has its own server and needs a token, Tempo Server is part of Jira.
+func newApi(client *http.Client, server *url.URL, userinfo *url.Userinfo, token, tempoUrl string, changes *journal.Changes) (Api, model.Api, error) {
+	// Worklogs are only changed by Tempo
+	jiraApi, err := jira.NewApi(client, server, userinfo, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get api version. %s", err.Error())
+	}
+	if !jira.IsCloud(jiraApi) {
+		path, _ := server.Parse(serverPath)
+		return journaled(&serverApi{
+			Client:   client,
+			Server:   path,
+			Userinfo: userinfo,
+			DryRun:   changes.Writer(),
+		}, server, changes), jiraApi, nil
+	}
+	if token == "" {
+		return nil, nil, fmt.Errorf("tempo cloud needs an api token")
+	}
+	if tempoUrl == "" {
+		tempoUrl = CloudUrl
+	}
+	if !strings.HasSuffix(tempoUrl, "/") {
+		tempoUrl += "/"
+	}
+	path, err := url.Parse(tempoUrl)
+	if err != nil {
+		return nil, nil, err
+	}
+	return journaled(&cloudApi{
+		Client: client,
+		Server: path,
+		Token:  token,
+		Jira:   jiraApi,
+		DryRun: changes.Writer(),
+	}, server, changes), jiraApi, nil
+}
+
+// GetTimesheet returns the effort of the current user or the given users.
+// The billable duration is the attribute "billable" and decides the billing status, the work attributes are kept by their key
+// and by the name of every given attribute, that refers to the key as field.
+func GetTimesheet(client *http.Client, server *url.URL, userinfo *url.Userinfo, token, tempoUrl string, year int, month time.Month, users []*pkg.User, attributes []pkg.Attribute) (pkg.Timesheet, error) {
+	api, jiraApi, err := newApi(client, server, userinfo, token, tempoUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	timesheet, err := worklogs(api, jiraApi, year, month, users, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("could not get worklog. %s", err.Error())
+	}
+	return timesheet, nil
+}
+
+// worklogs returns the effort of the current user or the given users.
+func worklogs(api Api, jiraApi model.Api, year int, month time.Month, users []*pkg.User, attributes []pkg.Attribute) (pkg.Timesheet, error) {
+	var accounts map[model.Account]*pkg.User
+	if len(users) == 0 {
+		account, location, err := jiraApi.Me()
+		if err != nil {
+			return nil, fmt.Errorf("could not get user. %s", err.Error())
+		}
+		accounts = map[model.Account]*pkg.User{account: {TimeZone: location}}
+	} else {
+		var err error
+		accounts, err = jira.Accounts(jiraApi, users)
+		if err != nil {
+			return nil, fmt.Errorf("could not get user. %s", err.Error())
+		}
+	}
+	ids := make([]model.Account, 0, len(accounts))
+	for account := range accounts {
+		ids = append(ids, account)
+	}
+
+	fromDate, toDate := pkg.GetTimeRange(year, month)
+	timesheet := pkg.Timesheet{}
+	err := api.Worklogs(ids, fromDate, toDate.AddDate(0, 0, -1), func(worklog Worklog) {
+		user := accounts[worklog.Account]
+		if user == nil {
+			return
+		}
+		timesheet = append(timesheet, effort(worklog, user, attributes))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timesheet, nil
+}
+
+// effort returns the worklog as effort of the user.
+func effort(worklog Worklog, user *pkg.User, names []pkg.Attribute) pkg.Effort {
+	location := user.TimeZone
+	if location == nil {
+		location = time.UTC
+	}
+	attributes := map[string]string{pkg.AttributeBillable: worklog.Billable.String()}
+	for key, value := range worklog.Attributes {
+		attributes[key] = value
+	}
+	for _, attribute := range names {
+		attributes[attribute.Name] = attributes[attribute.Field]
+	}
+	billing := pkg.BillingBillable
+	if worklog.Billable == 0 {
+		billing = pkg.BillingNonBillable
+	}
+	start := worklog.Start
+	return pkg.Effort{
+		Billing:     billing,
+		Id:          worklog.Id,
+		User:        user,
+		Project:     worklog.Project,
+		Task:        pkg.Task(worklog.Issue),
+		Description: worklog.Description,
+		Date:        time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+		Duration:    worklog.Duration,
+		Start:       time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, location),
+		Attributes:  attributes,
+	}
+}
+
+// AddWorklogItem adds the duration with the billable duration and the work attributes to the worklog of the task.
+func AddWorklogItem(client *http.Client, server *url.URL, userinfo *url.Userinfo, token, tempoUrl string, year int, month time.Month, day int, task pkg.Task, duration, billable time.Duration, description pkg.Description, attributes map[string]string, changes *journal.Changes) error {
+	api, jiraApi, err := newApi(client, server, userinfo, token, tempoUrl, changes)
+	if err != nil {
+		return err
+	}
+	account, location, err := jiraApi.Me()
+	if err != nil {
+		return fmt.Errorf("could not get user. %s", err.Error())
+	}
+	start := jira.AdjustDateTime(location, duration, year, month, day)
+	_, err = api.AddWorklog(Worklog{
+		Account:     account,
+		Issue:       model.IssueKey(task),
+		Start:       time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, time.UTC),
+		Duration:    duration,
+		Billable:    billable,
+		Description: description,
+		Attributes:  attributes,
+	})
+	if err != nil {
+		return fmt.Errorf("could not add effort. %s", err.Error())
+	}
+	return nil
+}
+
+// RemoveWorklogItem removes the selected worklogs of the current user, if they are confirmed.
+// Worklogs are read by their id or between the first and the last day of the selection.
+func RemoveWorklogItem(client *http.Client, server *url.URL, userinfo *url.Userinfo, token, tempoUrl string, selection pkg.Selection, confirm pkg.ConfirmFunc, changes *journal.Changes) error {
+	api, jiraApi, err := newApi(client, server, userinfo, token, tempoUrl, changes)
+	if err != nil {
+		return err
+	}
+	account, _, err := jiraApi.Me()
+	if err != nil {
+		return fmt.Errorf("could not get user. %s", err.Error())
+	}
+	user := &pkg.User{}
+	var timesheet pkg.Timesheet
+	switch {
+	case selection.Bounded():
+		err = api.Worklogs([]model.Account{account}, selection.From, selection.To, func(worklog Worklog) {
+			if worklog.Account == account {
+				timesheet = append(timesheet, effort(worklog, user, nil))
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("could not get worklog. %s", err.Error())
+		}
+	case len(selection.Ids) > 0:
+		for _, id := range selection.Ids {
+			worklog, err := api.Worklog(id)
+			if err != nil {
+				return fmt.Errorf("could not get worklog %s. %s", id, err.Error())
+			}
+			if worklog.Account != account {
+				return fmt.Errorf("worklog %s belongs to another user", id)
+			}
+			timesheet = append(timesheet, effort(worklog, user, nil))
+		}
+	default:
+		return fmt.Errorf("the selection needs worklog ids or a first and last day")
+	}
+	timesheet = timesheet.Select(selection)
+	if len(timesheet) == 0 {
+		return fmt.Errorf("found no worklog")
+	}
+
+	ok, err := confirm("Remove these worklogs", timesheet.Stringers())
+	if err != nil || !ok {
+		return err
+	}
+	for _, effort := range timesheet {
+		err = api.RemoveWorklog(effort.Id)
+		if err != nil {
+			return fmt.Errorf("could not remove effort. %s", err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package tempo
+
+import (
+	"bytes"
+	"eager/internal/fake"
+	"eager/pkg"
+	"eager/pkg/jira/model"
+	"encoding/json"
+	"github.com/magiconair/properties/assert"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type fakeJira map[string]model.IssueKey
+
+func (jira fakeJira) Issues(jql model.Jql, fields []string, issueFunc model.IssueFunc) error {
+	for id, key := range jira {
+		issueFunc(fake.Issue{IssueId: id, IssueKey: key})
+	}
+	return nil
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Header:     make(http.Header),
+	}
+}
+
+func TestCloudWorklogs(t *testing.T) {
+	server, _ := url.Parse(CloudUrl)
+	client := pkg.NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, req.Header.Get("Authorization"), "Bearer token")
+		if req.URL.Query().Get("offset") == "1" {
+			return response(200, `{"metadata":{},"results":[{"tempoWorklogId":2,"issue":{"id":10001},"timeSpentSeconds":1800,"billableSeconds":0,"startDate":"2022-08-02","startTime":"13:00:00","author":{"accountId":"a1"}}]}`)
+		}
+		assert.Equal(t, req.URL.Path, "/4/worklogs/user/a1")
+		assert.Equal(t, req.URL.Query().Get("from"), "2022-08-01")
+		return response(200, `{"metadata":{"next":"https://api.tempo.io/4/worklogs/user/a1?offset=1"},"results":[{"tempoWorklogId":1,"issue":{"id":10000},"timeSpentSeconds":3600,"billableSeconds":3600,"startDate":"2022-08-01","startTime":"09:30:00","description":"Fix","author":{"accountId":"a1"},"attributes":{"values":[{"key":"_Account_","value":"ACME"}]}}]}`)
+	})
+	api := cloudApi{Client: client, Server: server, Token: "token", Jira: fakeJira{"10000": "PROJ-1", "10001": "PROJ-2"}}
+
+	var worklogs []Worklog
+	err := api.Worklogs([]model.Account{"a1"}, time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 8, 31, 0, 0, 0, 0, time.UTC), func(worklog Worklog) {
+		worklogs = append(worklogs, worklog)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, worklogs, []Worklog{
+		{
+			Id:          "1",
+			Account:     "a1",
+			IssueId:     "10000",
+			Issue:       "PROJ-1",
+			Project:     "PROJ",
+			Start:       time.Date(2022, 8, 1, 9, 30, 0, 0, time.UTC),
+			Duration:    time.Hour,
+			Billable:    time.Hour,
+			Description: "Fix",
+			Attributes:  map[string]string{"_Account_": "ACME"},
+		},
+		{
+			Id:         "2",
+			Account:    "a1",
+			IssueId:    "10001",
+			Issue:      "PROJ-2",
+			Project:    "PROJ",
+			Start:      time.Date(2022, 8, 2, 13, 0, 0, 0, time.UTC),
+			Duration:   30 * time.Minute,
+			Attributes: map[string]string{},
+		},
+	})
+}
+
+func TestServerAddWorklog(t *testing.T) {
+	server, _ := url.Parse("https://jira.example.com" + serverPath)
+	client := pkg.NewTestClient(func(req *http.Request) *http.Response {
+		assert.Equal(t, req.Method, http.MethodPost)
+		assert.Equal(t, req.URL.Path, "/rest/tempo-timesheets/4/worklogs")
+		var item serverWorklogItem
+		_ = json.NewDecoder(req.Body).Decode(&item)
+		assert.Equal(t, item, serverWorklogItem{
+			Worker:           "JIRAUSER1",
+			OriginTaskId:     "PROJ-1",
+			Started:          "2022-08-01 09:30:00.000",
+			TimeSpentSeconds: 3600,
+			BillableSeconds:  1800,
+			Comment:          "Fix",
+			Attributes:       map[string]*serverAttribute{"_Account_": {Value: "ACME"}},
+		})
+		return response(200, `[{"tempoWorklogId":42}]`)
+	})
+	api := serverApi{Client: client, Server: server, Userinfo: url.UserPassword("user", "password")}
+
+	id, err := api.AddWorklog(Worklog{
+		Account:     "JIRAUSER1",
+		Issue:       "PROJ-1",
+		Start:       time.Date(2022, 8, 1, 9, 30, 0, 0, time.UTC),
+		Duration:    time.Hour,
+		Billable:    30 * time.Minute,
+		Description: "Fix",
+		Attributes:  map[string]string{"_Account_": "ACME"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, id, "42")
+}
+
+func TestServerDryRun(t *testing.T) {
+	server, _ := url.Parse("https://jira.example.com" + serverPath)
+	client := pkg.NewTestClient(func(req *http.Request) *http.Response {
+		t.Errorf("sent %s %s", req.Method, req.URL)
+		return response(500, "")
+	})
+	var out bytes.Buffer
+	api := serverApi{Client: client, Server: server, Userinfo: url.UserPassword("user", "password"), DryRun: &out}
+
+	_, err := api.AddWorklog(Worklog{Account: "JIRAUSER1", Issue: "PROJ-1", Start: time.Date(2022, 8, 1, 9, 30, 0, 0, time.UTC), Duration: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = api.RemoveWorklog("42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, out.String(), `POST https://jira.example.com/rest/tempo-timesheets/4/worklogs
+{"worker":"JIRAUSER1","originTaskId":"PROJ-1","started":"2022-08-01 09:30:00.000","timeSpentSeconds":3600,"billableSeconds":0,"comment":""}
+DELETE https://jira.example.com/rest/tempo-timesheets/4/worklogs/42
+`)
+}
+
+func TestEffort(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	effort := effort(Worklog{
+		Id:       "1",
+		Issue:    "PROJ-1",
+		Project:  "PROJ",
+		Start:    time.Date(2022, 8, 1, 23, 30, 0, 0, time.UTC),
+		Duration: time.Hour,
+		Billable: 30 * time.Minute,
+	}, &pkg.User{TimeZone: location}, pkg.Attributes([]string{"invoice=billable"}))
+	assert.Equal(t, effort.Date, time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, effort.Start, time.Date(2022, 8, 1, 23, 30, 0, 0, location))
+	assert.Equal(t, effort.Attributes, map[string]string{pkg.AttributeBillable: "30m0s", "invoice": "30m0s"})
+}
+
+func TestServerWorklogsInvalidStart(t *testing.T) {
+	server, _ := url.Parse("https://jira.example.com" + serverPath)
+	client := pkg.NewTestClient(func(req *http.Request) *http.Response {
+		return response(200, `[{"tempoWorklogId":1,"worker":"JIRAUSER1","started":"2022-08-01","timeSpentSeconds":3600,"issue":{"id":10000,"key":"PROJ-1","projectKey":"PROJ"}}]`)
+	})
+	api := serverApi{Client: client, Server: server, Userinfo: url.UserPassword("user", "password")}
+
+	err := api.Worklogs([]model.Account{"JIRAUSER1"}, time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 8, 31, 0, 0, 0, 0, time.UTC), func(worklog Worklog) {
+		t.Errorf("got worklog %v", worklog)
+	})
+	if err == nil {
+		t.Errorf("got no error for an invalid start")
+	}
+}
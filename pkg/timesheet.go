@@ -10,12 +10,20 @@ import (
 )
 
 type Effort struct {
+	// Id is the id of the worklog inside the store. Empty, if the store does not know it.
+	Id          string
 	User        *User
 	Project     Project
 	Task        Task
 	Description Description
 	Date        time.Time
 	Duration    time.Duration
+	// Start is the point in time the effort started in the time zone of the user. Zero, if the store does not know it.
+	Start time.Time
+	// Attributes are additional fields of the store by attribute name
+	Attributes map[string]string
+	// Billing is the status for invoicing. Unknown, if the store does not know it.
+	Billing Billing
 }
 
 type User struct {
@@ -143,9 +151,85 @@ func (ts Timesheet) summarize() Timesheet {
 	return timesheet
 }
 
-func (ts Timesheet) Print(writer io.Writer, user bool, opts *internal.DurationOptions) {
+type Day struct {
+	Date      time.Time
+	Timesheet Timesheet
+	Duration  time.Duration
+}
+
+// Days splits the timesheet into every day of the given month, including the days without effort.
+func (ts Timesheet) Days(year int, month time.Month) []Day {
+	fromDate, toDate := GetTimeRange(year, month)
+	var days []Day
+	for date := fromDate; date.Before(toDate); date = date.AddDate(0, 0, 1) {
+		days = append(days, Day{Date: date})
+	}
+	for _, effort := range append(Timesheet{}, ts...).sortByUserAndDateAndProjectAndTask() {
+		if effort.Date.Before(fromDate) || !effort.Date.Before(toDate) {
+			continue
+		}
+		day := &days[effort.Date.Day()-1]
+		day.Timesheet = append(day.Timesheet, effort)
+		day.Duration += effort.Duration
+	}
+	return days
+}
+
+func (ts Timesheet) Total() time.Duration {
+	var total time.Duration
+	for _, effort := range ts {
+		total += effort.Duration
+	}
+	return total
+}
+
+// fillEmptyDays adds an effort without duration for every day of the months a user has effort in, but no effort for that day.
+func (ts Timesheet) fillEmptyDays() Timesheet {
+	name := func(effort Effort) string {
+		if effort.User == nil {
+			return ""
+		}
+		return effort.User.DisplayName
+	}
+	endOfMonth := func(date time.Time) time.Time {
+		return time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	sorted := append(Timesheet{}, ts...).sortByUserAndDateAndProjectAndTask()
+	timesheet := append(Timesheet{}, ts...)
+	for i, effort := range sorted {
+		from := time.Date(effort.Date.Year(), effort.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if i > 0 {
+			previous := sorted[i-1]
+			if name(previous) == name(effort) && !from.After(previous.Date) {
+				from = previous.Date.AddDate(0, 0, 1)
+			} else {
+				timesheet = append(timesheet, emptyDaysBetween(previous.Date.AddDate(0, 0, 1), endOfMonth(previous.Date), previous.User)...)
+			}
+		}
+		timesheet = append(timesheet, emptyDaysBetween(from, effort.Date, effort.User)...)
+	}
+	if len(sorted) > 0 {
+		last := sorted[len(sorted)-1]
+		timesheet = append(timesheet, emptyDaysBetween(last.Date.AddDate(0, 0, 1), endOfMonth(last.Date), last.User)...)
+	}
+	return timesheet
+}
+
+// Print writes the timesheet as csv. The optional calendar marks the days with an absence.
+// With rounding, the rounded duration and the totals are added.
+func (ts Timesheet) Print(writer io.Writer, user bool, opts *internal.DurationOptions, calendar Calendar) {
+	if len(opts.GroupBy) > 0 {
+		ts.printGroups(writer, opts)
+		return
+	}
 	summarize := opts.Summarize
-	spec := NewCsvSpecification().User(user).Date(true).Project(!summarize).Task(!summarize).Duration(true).Description(!summarize)
+	spec := NewCsvSpecification().User(user).Date(true).Project(!summarize).Task(!summarize).Duration(true).Description(!summarize || calendar != nil).Calendar(calendar)
+	if opts.Rounding.Unit > 0 {
+		spec = spec.Rounded(ts.rounded(opts.Rounding, summarize))
+	}
+	if !summarize {
+		spec = spec.Attributes(opts.Columns)
+	}
 
 	timesheet := ts
 	if summarize {
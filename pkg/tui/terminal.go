@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+const (
+	keyRune = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyEnter
+	keyTab
+	keyBackspace
+	keyEscape
+	keyCtrlC
+)
+
+const (
+	escAlternateScreen = "\x1b[?1049h"
+	escMainScreen      = "\x1b[?1049l"
+	escHideCursor      = "\x1b[?25l"
+	escShowCursor      = "\x1b[?25h"
+	escClear           = "\x1b[H\x1b[2J"
+	escReverse         = "\x1b[7m"
+	escBold            = "\x1b[1m"
+	escReset           = "\x1b[0m"
+)
+
+type key struct {
+	code int
+	r    rune
+}
+
+// Run shows the user interface on the terminal until the user quits.
+func (ui *Ui) Run(in *os.File, out io.Writer) error {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("the terminal user interface needs a terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = fmt.Fprint(out, escShowCursor+escMainScreen)
+		_ = term.Restore(fd, state)
+	}()
+	_, _ = fmt.Fprint(out, escAlternateScreen+escHideCursor)
+
+	ui.load()
+	reader := bufio.NewReader(in)
+	for {
+		_, err = fmt.Fprint(out, escClear+ui.render())
+		if err != nil {
+			return err
+		}
+		k, err := readKey(reader)
+		if err != nil {
+			return err
+		}
+		if ui.handle(k) {
+			return nil
+		}
+	}
+}
+
+// readKey reads a key press from the terminal in raw mode.
+func readKey(reader *bufio.Reader) (key, error) {
+	r, _, err := reader.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+	switch r {
+	case '\r', '\n':
+		return key{code: keyEnter}, nil
+	case '\t':
+		return key{code: keyTab}, nil
+	case 127, '\b':
+		return key{code: keyBackspace}, nil
+	case 3:
+		return key{code: keyCtrlC}, nil
+	case 27:
+		// A single escape or the start of an escape sequence, which arrives at once
+		if reader.Buffered() == 0 {
+			return key{code: keyEscape}, nil
+		}
+		next, _, err := reader.ReadRune()
+		if err != nil || next != '[' {
+			return key{code: keyEscape}, err
+		}
+		code, _, err := reader.ReadRune()
+		if err != nil {
+			return key{}, err
+		}
+		switch code {
+		case 'A':
+			return key{code: keyUp}, nil
+		case 'B':
+			return key{code: keyDown}, nil
+		case 'C':
+			return key{code: keyRight}, nil
+		case 'D':
+			return key{code: keyLeft}, nil
+		case '5', '6':
+			// Page up and page down end with a tilde
+			_, _, _ = reader.ReadRune()
+			if code == '5' {
+				return key{code: keyPageUp}, nil
+			}
+			return key{code: keyPageDown}, nil
+		}
+		// Skip the rest of unknown sequences, the key is ignored
+		for reader.Buffered() > 0 {
+			_, _, _ = reader.ReadRune()
+		}
+		return key{code: keyRune}, nil
+	}
+	return key{code: keyRune, r: r}, nil
+}
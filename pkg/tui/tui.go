@@ -0,0 +1,378 @@
+package tui
+
+import (
+	"eager/pkg"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	focusCalendar = iota
+	focusList
+)
+
+const (
+	formNone = iota
+	formCancel
+	formSubmit
+)
+
+const cellWidth = 11
+
+var formLabels = []string{"Task", "Duration", "Description"}
+
+// Ui is the terminal user interface with a calendar of the month and the efforts of the selected day.
+type Ui struct {
+	provider  pkg.Provider
+	year      int
+	month     time.Month
+	day       int
+	timesheet pkg.Timesheet
+	focus     int
+	index     int
+	// form adds or edits an effort, if it is not nil
+	form *form
+	// confirm is the effort to remove, if it is not nil
+	confirm *pkg.Effort
+	message string
+}
+
+type form struct {
+	// effort is the effort to edit or nil for a new effort
+	effort *pkg.Effort
+	values []string
+	field  int
+}
+
+func New(provider pkg.Provider, year int, month time.Month, day int) *Ui {
+	return &Ui{
+		provider: provider,
+		year:     year,
+		month:    month,
+		day:      day,
+	}
+}
+
+func (ui *Ui) load() {
+	timesheet, err := ui.provider.Timesheet(ui.year, ui.month)
+	if err != nil {
+		ui.message = err.Error()
+		timesheet = pkg.Timesheet{}
+	}
+	ui.timesheet = timesheet
+	if efforts := ui.efforts(); ui.index >= len(efforts) {
+		ui.index = len(efforts) - 1
+	}
+	if ui.index < 0 {
+		ui.index = 0
+		ui.focus = focusCalendar
+	}
+}
+
+func (ui *Ui) date() time.Time {
+	return time.Date(ui.year, ui.month, ui.day, 0, 0, 0, 0, time.UTC)
+}
+
+// efforts returns the efforts of the selected day.
+func (ui *Ui) efforts() pkg.Timesheet {
+	days := ui.timesheet.Days(ui.year, ui.month)
+	if ui.day < 1 || ui.day > len(days) {
+		return nil
+	}
+	return days[ui.day-1].Timesheet
+}
+
+// move selects the day with the given distance and loads another month, if necessary.
+func (ui *Ui) move(days int) {
+	date := ui.date().AddDate(0, 0, days)
+	ui.day = date.Day()
+	ui.index = 0
+	if date.Year() != ui.year || date.Month() != ui.month {
+		ui.year, ui.month = date.Year(), date.Month()
+		ui.load()
+	}
+}
+
+// moveMonth selects the same day inside another month or the last day of that month.
+func (ui *Ui) moveMonth(months int) {
+	first := time.Date(ui.year, ui.month, 1, 0, 0, 0, 0, time.UTC).AddDate(0, months, 0)
+	last := first.AddDate(0, 1, -1).Day()
+	ui.year, ui.month = first.Year(), first.Month()
+	if ui.day > last {
+		ui.day = last
+	}
+	ui.index = 0
+	ui.load()
+}
+
+// handle processes the key and tells, if the user quits.
+func (ui *Ui) handle(k key) bool {
+	if k.code == keyCtrlC {
+		return true
+	}
+	if ui.confirm != nil {
+		if k.code == keyRune && k.r == 'y' {
+			ui.remove(*ui.confirm)
+		}
+		ui.confirm = nil
+		return false
+	}
+	if ui.form != nil {
+		switch ui.form.handle(k) {
+		case formCancel:
+			ui.form = nil
+		case formSubmit:
+			ui.submit()
+		}
+		return false
+	}
+
+	ui.message = ""
+	efforts := ui.efforts()
+	if k.code == keyRune {
+		switch k.r {
+		case 'q':
+			return true
+		case 'a':
+			ui.form = &form{values: make([]string, len(formLabels))}
+		case 'r':
+			ui.load()
+		case 'n':
+			ui.moveMonth(1)
+		case 'p':
+			ui.moveMonth(-1)
+		case 'e':
+			if ui.focus == focusList && len(efforts) > 0 {
+				ui.edit(efforts[ui.index])
+			}
+		case 'd':
+			if ui.focus == focusList && len(efforts) > 0 {
+				effort := efforts[ui.index]
+				ui.confirm = &effort
+			}
+		}
+		return false
+	}
+	switch k.code {
+	case keyPageUp:
+		ui.moveMonth(-1)
+	case keyPageDown:
+		ui.moveMonth(1)
+	}
+	if ui.focus == focusList {
+		switch k.code {
+		case keyUp:
+			if ui.index > 0 {
+				ui.index--
+			}
+		case keyDown:
+			if ui.index < len(efforts)-1 {
+				ui.index++
+			}
+		case keyEnter:
+			if len(efforts) > 0 {
+				ui.edit(efforts[ui.index])
+			}
+		case keyTab, keyEscape, keyLeft:
+			ui.focus = focusCalendar
+		}
+		return false
+	}
+	switch k.code {
+	case keyLeft:
+		ui.move(-1)
+	case keyRight:
+		ui.move(1)
+	case keyUp:
+		ui.move(-7)
+	case keyDown:
+		ui.move(7)
+	case keyEnter, keyTab:
+		if len(efforts) > 0 {
+			ui.focus = focusList
+			ui.index = 0
+		}
+	}
+	return false
+}
+
+func (ui *Ui) edit(effort pkg.Effort) {
+	ui.form = &form{
+		effort: &effort,
+		values: []string{string(effort.Task), effort.Duration.String(), string(effort.Description)},
+	}
+}
+
+// submit adds the effort of the form. An edited effort is added first and removed afterwards.
+func (ui *Ui) submit() {
+	task := strings.TrimSpace(ui.form.values[0])
+	if task == "" {
+		ui.message = "the task is missing"
+		return
+	}
+	duration, err := time.ParseDuration(strings.TrimSpace(ui.form.values[1]))
+	if err != nil || duration <= 0 {
+		ui.message = fmt.Sprintf("not a valid duration '%s'", ui.form.values[1])
+		return
+	}
+	effort := pkg.Effort{
+		Date: ui.date(),
+	}
+	old := ui.form.effort
+	if old != nil {
+		effort = *old
+		effort.Id = ""
+		effort.Attributes = map[string]string{}
+		for name, value := range old.Attributes {
+			effort.Attributes[name] = value
+		}
+		if duration != old.Duration {
+			// The billable duration of the store belongs to the old duration
+			delete(effort.Attributes, pkg.AttributeBillable)
+		}
+	}
+	effort.Task = pkg.Task(task)
+	effort.Duration = duration
+	effort.Description = pkg.Description(ui.form.values[2])
+
+	// Adding and removing belong to the same batch of changes
+	provider := pkg.Batched(ui.provider)
+	_, err = provider.Add(effort)
+	if err != nil {
+		ui.message = err.Error()
+		return
+	}
+	ui.form = nil
+	ui.message = fmt.Sprintf("Added %s", describe(effort))
+	if old != nil {
+		err = provider.Remove(*old)
+		if err != nil {
+			ui.message = err.Error()
+		} else {
+			ui.message = fmt.Sprintf("Changed %s", describe(effort))
+		}
+	}
+	ui.load()
+}
+
+func (ui *Ui) remove(effort pkg.Effort) {
+	err := pkg.Batched(ui.provider).Remove(effort)
+	if err != nil {
+		ui.message = err.Error()
+		return
+	}
+	ui.message = fmt.Sprintf("Removed %s", describe(effort))
+	ui.load()
+}
+
+// handle processes the key inside the form and returns the action.
+func (form *form) handle(k key) int {
+	switch k.code {
+	case keyEscape:
+		return formCancel
+	case keyTab, keyDown:
+		form.field = (form.field + 1) % len(form.values)
+	case keyUp:
+		form.field = (form.field + len(form.values) - 1) % len(form.values)
+	case keyEnter:
+		if form.field == len(form.values)-1 {
+			return formSubmit
+		}
+		form.field++
+	case keyBackspace:
+		value := []rune(form.values[form.field])
+		if len(value) > 0 {
+			form.values[form.field] = string(value[:len(value)-1])
+		}
+	case keyRune:
+		if k.r >= ' ' {
+			form.values[form.field] += string(k.r)
+		}
+	}
+	return formNone
+}
+
+// render returns the screen.
+func (ui *Ui) render() string {
+	var lines []string
+	days := ui.timesheet.Days(ui.year, ui.month)
+	lines = append(lines, fmt.Sprintf("%s%s %d%s   Total %s", escBold, ui.month, ui.year, escReset, hours(ui.timesheet.Total())))
+	lines = append(lines, "")
+
+	var header string
+	for i := 0; i < 7; i++ {
+		header += fmt.Sprintf(" %-*s", cellWidth-1, time.Weekday((i + 1) % 7).String()[:3])
+	}
+	lines = append(lines, header)
+	offset := (int(days[0].Date.Weekday()) + 6) % 7
+	line := strings.Repeat(" ", offset*cellWidth)
+	for _, day := range days {
+		cell := fmt.Sprintf(" %2d %-*s", day.Date.Day(), cellWidth-4, hours(day.Duration))
+		if day.Date.Day() == ui.day {
+			cell = escReverse + cell + escReset
+		}
+		line += cell
+		if day.Date.Weekday() == time.Sunday {
+			lines = append(lines, line)
+			line = ""
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", fmt.Sprintf("%s%s%s", escBold, ui.date().Format("Monday, 2006-01-02"), escReset))
+	efforts := ui.efforts()
+	if len(efforts) == 0 {
+		lines = append(lines, "  No effort")
+	}
+	for i, effort := range efforts {
+		line := fmt.Sprintf("  %-12s %-10s %6s  %s", effort.Task, effort.Project, hours(effort.Duration), effort.Description)
+		if ui.focus == focusList && i == ui.index {
+			line = escReverse + line + escReset
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "")
+	switch {
+	case ui.confirm != nil:
+		lines = append(lines, fmt.Sprintf("%sRemove %s (y/N)%s", escReverse, describe(*ui.confirm), escReset))
+	case ui.form != nil:
+		title := "Add effort"
+		if ui.form.effort != nil {
+			title = "Edit effort"
+		}
+		lines = append(lines, escBold+title+escReset)
+		for i, label := range formLabels {
+			marker, cursor := "  ", ""
+			if i == ui.form.field {
+				marker, cursor = "> ", "_"
+			}
+			lines = append(lines, fmt.Sprintf("%s%-12s %s%s", marker, label+":", ui.form.values[i], cursor))
+		}
+		lines = append(lines, "tab next field  enter save  esc cancel")
+	case ui.focus == focusList:
+		lines = append(lines, "up/down select  enter/e edit  d delete  a add  tab calendar  q quit")
+	default:
+		lines = append(lines, "arrows day  n/p month  enter efforts  a add  r reload  q quit")
+	}
+	if ui.message != "" {
+		lines = append(lines, ui.message)
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+// hours formats the duration as hours and minutes or empty, if there is no duration.
+func hours(duration time.Duration) string {
+	if duration == 0 {
+		return ""
+	}
+	minutes := int(duration.Round(time.Minute).Minutes())
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+func describe(effort pkg.Effort) string {
+	return fmt.Sprintf("%s;%s;%s;%s", effort.Task, effort.Date.Format(pkg.IsoYearMonthDay), effort.Duration, effort.Description)
+}
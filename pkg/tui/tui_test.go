@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"eager/internal/fake"
+	"eager/pkg"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func keys(text string, codes ...int) []key {
+	var result []key
+	for _, r := range text {
+		result = append(result, key{code: keyRune, r: r})
+	}
+	for _, code := range codes {
+		result = append(result, key{code: code})
+	}
+	return result
+}
+
+func TestUi(t *testing.T) {
+	date := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	provider := &fake.Provider{Efforts: pkg.Timesheet{
+		{Id: "1", Task: "PROJ-1", Date: date, Duration: time.Hour},
+	}}
+	ui := New(provider, 2022, time.August, 1)
+	ui.load()
+	if screen := ui.render(); !strings.Contains(screen, "Total 1:00") || !strings.Contains(screen, "PROJ-1") {
+		t.Errorf("got screen without effort\n%s", screen)
+	}
+
+	// Add an effort on the second day
+	var input []key
+	input = append(input, keys("", keyRight)...)
+	input = append(input, keys("a")...)
+	input = append(input, keys("PROJ-2", keyEnter)...)
+	input = append(input, keys("30m", keyEnter)...)
+	input = append(input, keys("Review", keyEnter)...)
+	for _, k := range input {
+		ui.handle(k)
+	}
+	if len(provider.Efforts) != 2 || provider.Efforts[1].Date != date.AddDate(0, 0, 1) || provider.Efforts[1].Description != "Review" {
+		t.Fatalf("got %v", provider.Efforts)
+	}
+
+	// Edit the duration of the effort
+	input = keys("", keyEnter, keyEnter, keyTab, keyBackspace, keyBackspace, keyBackspace, keyBackspace, keyBackspace)
+	input = append(input, keys("45m", keyEnter, keyEnter)...)
+	for _, k := range input {
+		ui.handle(k)
+	}
+	if len(provider.Efforts) != 2 || provider.Efforts[1].Duration != 45*time.Minute || provider.Efforts[1].Id != "3" {
+		t.Fatalf("got %v", provider.Efforts)
+	}
+
+	// Cancel and confirm the removal
+	for _, k := range keys("dnd") {
+		ui.handle(k)
+	}
+	if ui.confirm == nil || len(provider.Efforts) != 2 {
+		t.Fatalf("got removal without confirmation")
+	}
+	ui.handle(key{code: keyRune, r: 'y'})
+	if len(provider.Efforts) != 1 {
+		t.Fatalf("got %v", provider.Efforts)
+	}
+
+	// Move into the previous month
+	for _, k := range keys("", keyTab, keyLeft, keyLeft) {
+		ui.handle(k)
+	}
+	if ui.month != time.July || ui.day != 31 || provider.Loaded[len(provider.Loaded)-1] != time.July {
+		t.Errorf("got %s %d", ui.month, ui.day)
+	}
+	if !ui.handle(key{code: keyRune, r: 'q'}) {
+		t.Errorf("got no quit")
+	}
+}
+
+func TestUiError(t *testing.T) {
+	provider := &fake.Provider{Err: fmt.Errorf("could not get issues. 401 Unauthorized")}
+	ui := New(provider, 2022, time.August, 1)
+	ui.load()
+	if screen := ui.render(); !strings.Contains(screen, "could not get issues. 401 Unauthorized") {
+		t.Errorf("got screen without error\n%s", screen)
+	}
+}
@@ -0,0 +1,127 @@
+package pkg
+
+import (
+	"eager/internal"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		weekdays[name] = day
+		weekdays[name[:3]] = day
+	}
+}
+
+// WorkingTime is the expected effort of a user per weekday.
+type WorkingTime struct {
+	Days      map[time.Weekday]time.Duration
+	Carryover time.Duration
+	// Since is the first month the balance is calculated for. Zero, if only the requested month is calculated.
+	Since time.Time
+}
+
+// WorkingTimes contains the working time per user name.
+// The working time of the empty name is used for every user without own working time.
+type WorkingTimes map[string]*WorkingTime
+
+func ParseWeekday(name string) (time.Weekday, error) {
+	day, ok := weekdays[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown weekday '%s'", name)
+	}
+	return day, nil
+}
+
+func NewWorkingTimes(models []internal.WorkingTime) (WorkingTimes, error) {
+	result := WorkingTimes{}
+	for _, model := range models {
+		workingTime, err := newWorkingTime(model)
+		if err != nil {
+			return nil, fmt.Errorf("invalid working time for '%s'. %s", model.User, err.Error())
+		}
+		result[model.User] = workingTime
+	}
+	return result, nil
+}
+
+func newWorkingTime(model internal.WorkingTime) (*WorkingTime, error) {
+	workingTime := &WorkingTime{
+		Days:      map[time.Weekday]time.Duration{},
+		Carryover: model.Carryover,
+	}
+	if model.Since != "" {
+		since, err := time.Parse(IsoYearMonth, model.Since)
+		if err != nil {
+			return nil, fmt.Errorf("since must be formatted as %s", IsoYearMonth)
+		}
+		workingTime.Since = since
+	}
+
+	names := model.Weekdays
+	if len(names) == 0 {
+		names = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+	}
+	days := make([]time.Weekday, len(names))
+	for i, name := range names {
+		day, err := ParseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		days[i] = day
+	}
+	switch {
+	case model.Daily > 0 && model.Weekly > 0:
+		return nil, fmt.Errorf("either daily or weekly hours are allowed")
+	case model.Daily > 0:
+		for _, day := range days {
+			workingTime.Days[day] = model.Daily
+		}
+	case model.Weekly > 0:
+		for _, day := range days {
+			workingTime.Days[day] = model.Weekly / time.Duration(len(days))
+		}
+	}
+	// Explicit durations per weekday take precedence
+	for name, duration := range model.Days {
+		day, err := ParseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		workingTime.Days[day] = duration
+	}
+	return workingTime, nil
+}
+
+// Get returns the working time of the user or the default working time.
+func (wt WorkingTimes) Get(user *User) *WorkingTime {
+	if user != nil {
+		if workingTime := wt[user.DisplayName]; workingTime != nil {
+			return workingTime
+		}
+	}
+	return wt[""]
+}
+
+// Since returns the first month of the earliest working time, that starts before the given date, or the given date.
+func (wt WorkingTimes) Since(date time.Time) time.Time {
+	since := date
+	for _, workingTime := range wt {
+		if !workingTime.Since.IsZero() && workingTime.Since.Before(since) {
+			since = workingTime.Since
+		}
+	}
+	return since
+}
+
+// Target returns the expected effort for the given date.
+func (wt *WorkingTime) Target(date time.Time) time.Duration {
+	if wt == nil {
+		return 0
+	}
+	return wt.Days[date.Weekday()]
+}
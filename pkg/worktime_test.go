@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"eager/internal"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewWorkingTimes(t *testing.T) {
+	hours := func(hours float64) time.Duration { return time.Duration(hours * float64(time.Hour)) }
+	tests := []struct {
+		name  string
+		model internal.WorkingTime
+		want  map[time.Weekday]time.Duration
+		err   bool
+	}{
+		{
+			name:  "daily",
+			model: internal.WorkingTime{Daily: hours(8)},
+			want:  map[time.Weekday]time.Duration{time.Monday: hours(8), time.Tuesday: hours(8), time.Wednesday: hours(8), time.Thursday: hours(8), time.Friday: hours(8)},
+		},
+		{
+			name:  "weekly on weekdays",
+			model: internal.WorkingTime{Weekly: hours(30), Weekdays: []string{"mon", "Tuesday", "wed", "thu"}},
+			want:  map[time.Weekday]time.Duration{time.Monday: hours(7.5), time.Tuesday: hours(7.5), time.Wednesday: hours(7.5), time.Thursday: hours(7.5)},
+		},
+		{
+			name:  "days take precedence",
+			model: internal.WorkingTime{Daily: hours(8), Days: map[string]time.Duration{"friday": hours(4), "saturday": hours(2)}},
+			want:  map[time.Weekday]time.Duration{time.Monday: hours(8), time.Tuesday: hours(8), time.Wednesday: hours(8), time.Thursday: hours(8), time.Friday: hours(4), time.Saturday: hours(2)},
+		},
+		{name: "daily and weekly", model: internal.WorkingTime{Daily: hours(8), Weekly: hours(40)}, err: true},
+		{name: "unknown weekday", model: internal.WorkingTime{Daily: hours(8), Weekdays: []string{"someday"}}, err: true},
+		{name: "invalid since", model: internal.WorkingTime{Daily: hours(8), Since: "2022-08-01"}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingTimes, err := NewWorkingTimes([]internal.WorkingTime{tt.model})
+			if (err != nil) != tt.err {
+				t.Fatalf("got error %v", err)
+			}
+			if tt.err {
+				return
+			}
+			if got := workingTimes.Get(nil).Days; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingTimesGet(t *testing.T) {
+	workingTimes, err := NewWorkingTimes([]internal.WorkingTime{
+		{Daily: 8 * time.Hour},
+		{User: "Jane Doe", Daily: 4 * time.Hour},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		user *User
+		want time.Duration
+	}{
+		{nil, 8 * time.Hour},
+		{&User{DisplayName: "John Doe"}, 8 * time.Hour},
+		{&User{DisplayName: "Jane Doe"}, 4 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := workingTimes.Get(tt.user).Target(monday); got != tt.want {
+			t.Errorf("got %s want %s for %v", got, tt.want, tt.user)
+		}
+	}
+	if got := workingTimes.Get(nil).Target(monday.AddDate(0, 0, 5)); got != 0 {
+		t.Errorf("got %s want no target on saturday", got)
+	}
+	if got := (WorkingTimes{}).Get(nil).Target(monday); got != 0 {
+		t.Errorf("got %s want no target without working time", got)
+	}
+}
@@ -0,0 +1,217 @@
+package pkg
+
+import (
+	"archive/zip"
+	"eager/internal"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	xlsxStyleDefault = 0
+	xlsxStyleDate    = 1
+	xlsxStyleHours   = 2
+	xlsxStyleHeader  = 3
+)
+
+// Excel counts days since 1899-12-30, including the non-existing 1900-02-29.
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+type xlsxSheet struct {
+	name string
+	rows [][]xlsxCell
+}
+
+type xlsxCell struct {
+	value interface{}
+	style int
+}
+
+func (sheet *xlsxSheet) add(cells ...xlsxCell) {
+	sheet.rows = append(sheet.rows, cells)
+}
+
+func xlsxHeader(names ...string) []xlsxCell {
+	cells := make([]xlsxCell, len(names))
+	for i, name := range names {
+		cells[i] = xlsxCell{name, xlsxStyleHeader}
+	}
+	return cells
+}
+
+// WriteXlsx writes the timesheet as workbook with the raw efforts, a summary per day and a summary per user.
+// Dates and durations are written as numbers, so that they can be used for calculations right away.
+func (ts Timesheet) WriteXlsx(writer io.Writer, user bool, opts *internal.DurationOptions) error {
+	hours := func(duration time.Duration) xlsxCell {
+		value := duration.Hours()
+		if opts.Negate {
+			value = -value
+		}
+		return xlsxCell{value, xlsxStyleHours}
+	}
+	date := func(date time.Time) xlsxCell {
+		return xlsxCell{date, xlsxStyleDate}
+	}
+	name := func(effort Effort) xlsxCell {
+		if effort.User == nil {
+			return xlsxCell{"", xlsxStyleDefault}
+		}
+		return xlsxCell{effort.User.DisplayName, xlsxStyleDefault}
+	}
+
+	efforts := &xlsxSheet{name: "Efforts"}
+	efforts.add(xlsxHeader("User", "Date", "Project", "Task", "Description", "Hours")...)
+	for _, effort := range append(Timesheet{}, ts...).sortByUserAndDateAndProjectAndTask() {
+		efforts.add(
+			name(effort),
+			date(effort.Date),
+			xlsxCell{string(effort.Project), xlsxStyleDefault},
+			xlsxCell{string(effort.Task), xlsxStyleDefault},
+			xlsxCell{string(effort.Description), xlsxStyleDefault},
+			hours(effort.Duration),
+		)
+	}
+
+	days := &xlsxSheet{name: "Days"}
+	days.add(xlsxHeader("User", "Date", "Hours")...)
+	summary := ts.summarize()
+	if opts.Empty {
+		summary = summary.fillEmptyDays()
+	}
+	for _, effort := range summary.sortByUserAndDateAndProjectAndTask() {
+		days.add(name(effort), date(effort.Date), hours(effort.Duration))
+	}
+
+	users := &xlsxSheet{name: "Users"}
+	users.add(xlsxHeader("User", "Hours")...)
+	total := map[string]time.Duration{}
+	for _, effort := range ts {
+		total[name(effort).value.(string)] += effort.Duration
+	}
+	names := make([]string, 0, len(total))
+	for name := range total {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		users.add(xlsxCell{name, xlsxStyleDefault}, hours(total[name]))
+	}
+
+	if !user {
+		// Drop the user column for a single user timesheet
+		for _, sheet := range []*xlsxSheet{efforts, days} {
+			for i, row := range sheet.rows {
+				sheet.rows[i] = row[1:]
+			}
+		}
+	}
+
+	return writeXlsx(writer, efforts, days, users)
+}
+
+func writeXlsx(writer io.Writer, sheets ...*xlsxSheet) error {
+	archive := zip.NewWriter(writer)
+	add := func(name string, content string) error {
+		file, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(file, xml.Header+content)
+		return err
+	}
+
+	var overrides, entries, relations strings.Builder
+	for i, sheet := range sheets {
+		id := i + 1
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, id)
+		fmt.Fprintf(&entries, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(sheet.name), id, id)
+		fmt.Fprintf(&relations, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, id, id)
+	}
+	fmt.Fprintf(&relations, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + entries.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			relations.String() + `</Relationships>`},
+		// The cell formats are referenced by the xlsxStyle constants
+		{"xl/styles.xml", `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy\-mm\-dd"/></numFmts>` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="4">` +
+			`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+			`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+			`<xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+			`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+			`</cellXfs></styleSheet>`},
+	}
+	for _, file := range files {
+		err := add(file.name, file.content)
+		if err != nil {
+			return err
+		}
+	}
+	for i, sheet := range sheets {
+		err := add(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml())
+		if err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func (sheet *xlsxSheet) xml() string {
+	var content strings.Builder
+	content.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range sheet.rows {
+		fmt.Fprintf(&content, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			ref := fmt.Sprintf("%s%d", xlsxColumn(j), i+1)
+			switch value := cell.value.(type) {
+			case time.Time:
+				days := value.Sub(xlsxEpoch).Hours() / 24
+				fmt.Fprintf(&content, `<c r="%s" s="%d"><v>%g</v></c>`, ref, cell.style, days)
+			case float64:
+				fmt.Fprintf(&content, `<c r="%s" s="%d"><v>%g</v></c>`, ref, cell.style, value)
+			default:
+				fmt.Fprintf(&content, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.style, xlsxEscape(fmt.Sprint(value)))
+			}
+		}
+		content.WriteString(`</row>`)
+	}
+	content.WriteString(`</sheetData></worksheet>`)
+	return content.String()
+}
+
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+func xlsxEscape(text string) string {
+	var result strings.Builder
+	_ = xml.EscapeText(&result, []byte(text))
+	return result.String()
+}
@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"eager/internal"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// readXlsx returns the text or number of every cell per sheet name.
+func readXlsx(t *testing.T, data []byte) map[string][][]string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	read := func(name string, value interface{}) {
+		file, err := archive.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = xml.Unmarshal(content, value)
+		if err != nil {
+			t.Fatalf("not a valid %s. %s", name, err.Error())
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		var document struct{}
+		read(name, &document)
+	}
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	read("xl/workbook.xml", &workbook)
+	result := map[string][][]string{}
+	for i, sheet := range workbook.Sheets {
+		var worksheet struct {
+			Rows []struct {
+				Cells []struct {
+					Value string `xml:"v"`
+					Text  string `xml:"is>t"`
+				} `xml:"c"`
+			} `xml:"sheetData>row"`
+		}
+		read(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), &worksheet)
+		for _, row := range worksheet.Rows {
+			var cells []string
+			for _, cell := range row.Cells {
+				cells = append(cells, cell.Value+cell.Text)
+			}
+			result[sheet.Name] = append(result[sheet.Name], cells)
+		}
+	}
+	return result
+}
+
+func TestWriteXlsx(t *testing.T) {
+	jane := &User{DisplayName: "Jane Doe"}
+	timesheet := Timesheet{
+		{User: jane, Project: "PROJ", Task: "PROJ-1", Description: "Fix <login> & test", Date: time.Date(2022, time.August, 2, 0, 0, 0, 0, time.UTC), Duration: 90 * time.Minute},
+		{User: jane, Project: "PROJ", Task: "PROJ-2", Date: time.Date(2022, time.August, 2, 0, 0, 0, 0, time.UTC), Duration: 30 * time.Minute},
+	}
+	var buffer bytes.Buffer
+	err := timesheet.WriteXlsx(&buffer, true, &internal.DurationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := readXlsx(t, buffer.Bytes())
+	want := map[string][][]string{
+		"Efforts": {
+			{"User", "Date", "Project", "Task", "Description", "Hours"},
+			{"Jane Doe", "44775", "PROJ", "PROJ-1", "Fix <login> & test", "1.5"},
+			{"Jane Doe", "44775", "PROJ", "PROJ-2", "", "0.5"},
+		},
+		"Days": {
+			{"User", "Date", "Hours"},
+			{"Jane Doe", "44775", "2"},
+		},
+		"Users": {
+			{"User", "Hours"},
+			{"Jane Doe", "2"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestFillEmptyDays(t *testing.T) {
+	jane := &User{DisplayName: "Jane Doe"}
+	john := &User{DisplayName: "John Doe"}
+	timesheet := Timesheet{
+		{User: john, Date: time.Date(2022, time.February, 28, 0, 0, 0, 0, time.UTC), Duration: time.Hour},
+		{User: jane, Date: time.Date(2022, time.February, 3, 0, 0, 0, 0, time.UTC), Duration: time.Hour},
+		{User: jane, Date: time.Date(2022, time.February, 3, 0, 0, 0, 0, time.UTC), Duration: time.Hour},
+		{User: jane, Date: time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC), Duration: time.Hour},
+	}
+	days := map[string]int{}
+	for _, effort := range timesheet.fillEmptyDays() {
+		days[effort.User.DisplayName+" "+effort.Date.Format(IsoYearMonth)]++
+	}
+	want := map[string]int{"Jane Doe 2022-02": 29, "Jane Doe 2022-04": 30, "John Doe 2022-02": 28}
+	if !reflect.DeepEqual(days, want) {
+		t.Errorf("got %v want %v", days, want)
+	}
+}